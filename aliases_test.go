@@ -0,0 +1,56 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type aliasedConfig struct {
+	Address string
+	Port    int
+	Name    string
+}
+
+func TestFieldAliases(t *testing.T) {
+	aliases := mergo.WithFieldAliases(map[string][]string{
+		"Address": {"address", "addr", "host"},
+		"Port":    {"port", "listen_port"},
+	})
+	testCases := []struct {
+		name     string
+		src      map[string]interface{}
+		expected aliasedConfig
+	}{
+		{"alias", map[string]interface{}{"addr": "a", "listen_port": 80}, aliasedConfig{Address: "a", Port: 80}},
+		{"priority", map[string]interface{}{"host": "h", "addr": "a", "address": "full"}, aliasedConfig{Address: "full"}},
+		{"later alias", map[string]interface{}{"host": "h", "addr": "a"}, aliasedConfig{Address: "a"}},
+		{"absent", map[string]interface{}{"name": "n"}, aliasedConfig{Name: "n"}},
+		{"not listed", map[string]interface{}{"Address": "a", "name": "n"}, aliasedConfig{Name: "n"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := aliasedConfig{}
+			if err := mergo.Map(&dst, tc.src, aliases); err != nil {
+				t.Fatal(err)
+			}
+			if dst != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, dst)
+			}
+		})
+	}
+}
+
+func TestFieldAliasesRequired(t *testing.T) {
+	opts := []func(*mergo.Config){
+		mergo.WithFieldAliases(map[string][]string{"Address": {"address", "addr"}}),
+		mergo.WithRequiredFields([]string{"Address"}),
+	}
+	dst := aliasedConfig{}
+	if err := mergo.Map(&dst, map[string]interface{}{"addr": "a"}, opts...); err != nil {
+		t.Errorf("expected an alias to satisfy a required field, got %v", err)
+	}
+	if err := mergo.Map(&dst, map[string]interface{}{"Address": "a"}, opts...); err == nil {
+		t.Error("expected a key not listed as alias not to satisfy a required field")
+	}
+}
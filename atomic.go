@@ -0,0 +1,41 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// MergeAtomic merges src into a deep copy of the value stored in v, which is
+// then stored back, so readers loading v always see a consistent snapshot.
+// If v is empty, a copy of src is stored. The value stored in v can be a
+// struct or a map, or a pointer to one of them.
+// Merges are lock-free for readers, but concurrent MergeAtomic calls on the
+// same v must be serialized by the caller, otherwise updates can be lost.
+func MergeAtomic(v *atomic.Value, src interface{}, opts ...func(*Config)) error {
+	if v == nil || src == nil {
+		return ErrNilArguments
+	}
+	current := v.Load()
+	if current == nil {
+		v.Store(clone(src))
+		return nil
+	}
+	next := reflect.ValueOf(clone(current))
+	dst := next
+	if dst.Kind() != reflect.Ptr {
+		dst = reflect.New(next.Type())
+		dst.Elem().Set(next)
+	}
+	if err := merge(dst.Interface(), src, opts...); err != nil {
+		return err
+	}
+	if next.Kind() != reflect.Ptr {
+		next = dst.Elem()
+	}
+	v.Store(next.Interface())
+	return nil
+}
@@ -0,0 +1,92 @@
+package mergo_test
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type atomicConfig struct {
+	Version int
+	Name    string
+	Labels  map[string]string
+	Hosts   []string
+}
+
+func TestMergeAtomic(t *testing.T) {
+	initial := &atomicConfig{Version: 1, Labels: map[string]string{"env": "dev"}}
+	var v atomic.Value
+	v.Store(initial)
+
+	src := atomicConfig{Version: 2, Name: "reloaded", Labels: map[string]string{"team": "core"}}
+	if err := mergo.MergeAtomic(&v, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	got := v.Load().(*atomicConfig)
+	expected := &atomicConfig{Version: 2, Name: "reloaded", Labels: map[string]string{"env": "dev", "team": "core"}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got == initial || len(initial.Labels) != 1 || initial.Version != 1 {
+		t.Errorf("the previous snapshot must be left untouched, got %v", initial)
+	}
+}
+
+func TestMergeAtomicValues(t *testing.T) {
+	var v atomic.Value
+	if err := mergo.MergeAtomic(&v, atomicConfig{Name: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mergo.MergeAtomic(&v, atomicConfig{Name: "second", Version: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (atomicConfig{Name: "first", Version: 3}); !reflect.DeepEqual(v.Load(), expected) {
+		t.Errorf("expected %v, got %v", expected, v.Load())
+	}
+}
+
+func TestMergeAtomicConcurrentReaders(t *testing.T) {
+	var v atomic.Value
+	v.Store(&atomicConfig{Labels: map[string]string{}, Hosts: []string{"a"}})
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				cfg := v.Load().(*atomicConfig)
+				if len(cfg.Labels) != cfg.Version {
+					t.Errorf("inconsistent snapshot: %d labels at version %d", len(cfg.Labels), cfg.Version)
+					return
+				}
+				_ = cfg.Hosts[len(cfg.Hosts)-1]
+			}
+		}()
+	}
+	for i := 1; i <= 50; i++ {
+		src := atomicConfig{
+			Version: i,
+			Labels:  map[string]string{string(rune('a' + i)): "x"},
+			Hosts:   []string{"b"},
+		}
+		if err := mergo.MergeAtomic(&v, src, mergo.WithOverride, mergo.WithAppendSlice); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if cfg := v.Load().(*atomicConfig); cfg.Version != 50 || len(cfg.Hosts) != 51 {
+		t.Errorf("expected every merge to be applied, got version %d with %d hosts", cfg.Version, len(cfg.Hosts))
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"math/big"
+	"reflect"
+)
+
+var (
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+	bigIntType   = reflect.TypeOf(big.Int{})
+)
+
+func isBigNumberType(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ == bigFloatType || typ == bigRatType || typ == bigIntType
+}
+
+// mergeBigNumber merges src into dst, both big.Float, big.Rat or big.Int values
+// or pointers. They are opaque values, so they are replaced as a whole by a
+// copy of src, a big.Float keeping its precision and rounding mode, instead
+// of sharing the words of src. It reports whether src was handled.
+func mergeBigNumber(dst, src reflect.Value, overwrite, overwriteWithEmptySrc bool, config *Config) bool {
+	if src.Type() != dst.Type() || !dst.CanSet() || !src.CanInterface() {
+		return false
+	}
+	var dstEmpty, srcEmpty bool
+	if src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			// Let nil pointers follow the usual rules.
+			return false
+		}
+		dstEmpty = dst.IsNil()
+	} else {
+		dstEmpty, srcEmpty = isEmptyStruct(dst), isEmptyStruct(src)
+	}
+	if !(dstEmpty || overwrite) || (srcEmpty && !overwriteWithEmptySrc) {
+		traceDecision(config, TraceKeep, dst, dst)
+		return true
+	}
+	ptr := src
+	if src.Kind() != reflect.Ptr {
+		ptr = reflect.New(src.Type())
+		ptr.Elem().Set(src)
+	}
+	copied := reflect.ValueOf(copyBigNumber(ptr.Interface()))
+	if src.Kind() != reflect.Ptr {
+		copied = copied.Elem()
+	}
+	traceDecision(config, TraceSet, dst, src)
+	dst.Set(copied)
+	return true
+}
+
+func copyBigNumber(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *big.Float:
+		return new(big.Float).SetPrec(v.Prec()).SetMode(v.Mode()).Set(v)
+	case *big.Rat:
+		return new(big.Rat).Set(v)
+	case *big.Int:
+		return new(big.Int).Set(v)
+	}
+	return v
+}
@@ -0,0 +1,70 @@
+package mergo_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type ledger struct {
+	Balance *big.Float
+	Ratio   *big.Rat
+	Count   *big.Int
+	Rate    big.Float
+}
+
+func newLedger() ledger {
+	return ledger{
+		Balance: new(big.Float).SetPrec(200).SetMode(big.ToZero).SetFloat64(1.5),
+		Ratio:   big.NewRat(1, 3),
+		Count:   big.NewInt(42),
+		Rate:    *new(big.Float).SetPrec(100).SetFloat64(0.25),
+	}
+}
+
+func TestMergeBigNumbers(t *testing.T) {
+	src := newLedger()
+	dst := ledger{}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Balance == src.Balance || dst.Ratio == src.Ratio || dst.Count == src.Count {
+		t.Fatalf("expected big numbers to be copied, got shared pointers")
+	}
+	if dst.Balance.Prec() != 200 || dst.Balance.Mode() != big.ToZero || dst.Rate.Prec() != 100 {
+		t.Errorf("expected precision and mode to be kept, got %d, %v and %d", dst.Balance.Prec(), dst.Balance.Mode(), dst.Rate.Prec())
+	}
+
+	src.Balance.SetFloat64(3)
+	src.Ratio.SetInt64(2)
+	src.Count.SetInt64(7)
+	src.Rate.SetFloat64(9)
+	if dst.Balance.String() != "1.5" || dst.Ratio.String() != "1/3" || dst.Count.String() != "42" || dst.Rate.String() != "0.25" {
+		t.Errorf("expected dst not to change with src, got %v, %v, %v and %v", dst.Balance, dst.Ratio, dst.Count, dst.Rate.String())
+	}
+}
+
+func TestMergeBigNumbersOverride(t *testing.T) {
+	dst := ledger{Balance: big.NewFloat(10), Rate: *big.NewFloat(1)}
+	src := newLedger()
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Balance.String() != "10" || dst.Rate.String() != "1" {
+		t.Errorf("expected dst values to be kept without override, got %v and %v", dst.Balance, dst.Rate.String())
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Balance.String() != "1.5" || dst.Rate.String() != "0.25" || dst.Rate.Prec() != 100 {
+		t.Errorf("expected src values with override, got %v and %v", dst.Balance, dst.Rate.String())
+	}
+	src.Rate.SetFloat64(9)
+	if dst.Rate.String() != "0.25" {
+		t.Errorf("expected the overridden value not to share src's mantissa, got %v", dst.Rate.String())
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"encoding"
+	"reflect"
+)
+
+var binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+
+// unmarshalBinaryInto decodes src into dst with UnmarshalBinary if src is a
+// []byte and dst implements encoding.BinaryUnmarshaler, see
+// WithBinaryUnmarshal. It reports whether src was handled.
+func unmarshalBinaryInto(dst, src reflect.Value, overwrite bool) (bool, error) {
+	if src.Kind() != reflect.Slice || src.Type().Elem().Kind() != reflect.Uint8 || dst.Type() == src.Type() {
+		return false, nil
+	}
+	typ := dst.Type()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if !reflect.PtrTo(typ).Implements(binaryUnmarshalerType) {
+		return false, nil
+	}
+	if !overwrite && !isEmptyValue(dst) && !(dst.Kind() == reflect.Struct && isEmptyStruct(dst)) {
+		return true, nil
+	}
+	// Decode into a fresh value, so a failure leaves dst untouched.
+	value := reflect.New(typ)
+	if err := value.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(src.Bytes()); err != nil {
+		return true, err
+	}
+	if dst.Kind() == reflect.Ptr {
+		dst.Set(value)
+	} else {
+		dst.Set(value.Elem())
+	}
+	return true, nil
+}
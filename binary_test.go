@@ -0,0 +1,71 @@
+package mergo_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type binaryVersion struct {
+	Major, Minor uint16
+}
+
+func (v *binaryVersion) UnmarshalBinary(data []byte) error {
+	if len(data) != 4 {
+		return errors.New("invalid version")
+	}
+	v.Major = binary.BigEndian.Uint16(data)
+	v.Minor = binary.BigEndian.Uint16(data[2:])
+	return nil
+}
+
+type binaryConfig struct {
+	Version    binaryVersion
+	MinVersion *binaryVersion
+}
+
+func TestBinaryUnmarshal(t *testing.T) {
+	var dst binaryConfig
+	src := map[string]interface{}{
+		"version":    []byte{0, 1, 0, 2},
+		"minVersion": []byte{0, 1, 0, 0},
+	}
+
+	if err := mergo.Map(&dst, src, mergo.WithBinaryUnmarshal); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Version != (binaryVersion{1, 2}) {
+		t.Errorf("expected version 1.2, got %v", dst.Version)
+	}
+	if dst.MinVersion == nil || *dst.MinVersion != (binaryVersion{1, 0}) {
+		t.Errorf("expected min version 1.0, got %v", dst.MinVersion)
+	}
+
+	src = map[string]interface{}{"version": []byte{0, 3, 0, 0}}
+	if err := mergo.Map(&dst, src, mergo.WithBinaryUnmarshal); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Version != (binaryVersion{1, 2}) {
+		t.Errorf("expected version to be kept without WithOverride, got %v", dst.Version)
+	}
+	if err := mergo.Map(&dst, src, mergo.WithBinaryUnmarshal, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Version != (binaryVersion{3, 0}) {
+		t.Errorf("expected version 3.0 with WithOverride, got %v", dst.Version)
+	}
+}
+
+func TestBinaryUnmarshalError(t *testing.T) {
+	dst := binaryConfig{Version: binaryVersion{1, 2}}
+	src := map[string]interface{}{"version": []byte{1}}
+
+	if err := mergo.Map(&dst, src, mergo.WithBinaryUnmarshal, mergo.WithOverride); err == nil {
+		t.Error("expected an error for the invalid version")
+	}
+	if dst.Version != (binaryVersion{1, 2}) {
+		t.Errorf("expected version to be untouched, got %v", dst.Version)
+	}
+}
@@ -0,0 +1,75 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type boxedConfig struct {
+	Host string
+	Port int
+}
+
+func TestMapBoxesValuesIntoPointerMap(t *testing.T) {
+	existing := &boxedConfig{Host: "db"}
+	dst := map[string]*boxedConfig{"db": existing, "nil": nil}
+	src := map[string]boxedConfig{
+		"db":    {Host: "other", Port: 5432},
+		"cache": {Host: "redis", Port: 6379},
+		"nil":   {Host: "allocated"},
+	}
+
+	if err := mergo.Map(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst["db"] != existing || *existing != (boxedConfig{Host: "db", Port: 5432}) {
+		t.Errorf("expected src to be merged into the existing pointer, got %v", dst["db"])
+	}
+	if dst["cache"] == nil || *dst["cache"] != src["cache"] {
+		t.Errorf("expected a pointer to a copy of src, got %v", dst["cache"])
+	}
+	if dst["nil"] == nil || dst["nil"].Host != "allocated" {
+		t.Errorf("expected nil pointers to be allocated, got %v", dst["nil"])
+	}
+
+	dst["cache"].Port = 1
+	if src["cache"].Port != 6379 {
+		t.Error("dst must not share storage with src")
+	}
+}
+
+func TestMapBoxesInterfaceValuesWithOverride(t *testing.T) {
+	dst := map[string]*boxedConfig{"db": {Host: "db", Port: 1}}
+	src := map[string]interface{}{"db": boxedConfig{Host: "other"}}
+
+	if err := mergo.Map(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if *dst["db"] != (boxedConfig{Host: "other", Port: 1}) {
+		t.Errorf("expected %v, got %v", boxedConfig{Host: "other", Port: 1}, *dst["db"])
+	}
+}
+
+func TestMergeIntoInterfaceHeldPointers(t *testing.T) {
+	existing := &boxedConfig{Host: "db"}
+	dst := map[string]interface{}{"db": existing, "name": "dst"}
+	src := map[string]interface{}{"db": boxedConfig{Host: "other", Port: 5432}, "cache": boxedConfig{Host: "redis"}}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst["db"] != existing || *existing != (boxedConfig{Host: "db", Port: 5432}) {
+		t.Errorf("expected src to be merged into the held pointer, got %v", dst["db"])
+	}
+	if dst["cache"] != src["cache"] {
+		t.Errorf("expected missing keys to be set as is, got %v", dst["cache"])
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst["db"] != existing || existing.Host != "other" {
+		t.Errorf("expected the pointee to be overridden in place, got %v", dst["db"])
+	}
+}
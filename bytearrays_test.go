@@ -0,0 +1,52 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type uuid [16]byte
+
+type identified struct {
+	ID   uuid
+	Hash [32]byte
+	Name string
+}
+
+func TestMergeByteArrays(t *testing.T) {
+	src := identified{ID: uuid{1, 2, 3, 4}, Hash: [32]byte{31: 9}, Name: "src"}
+	dst := identified{}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst != src {
+		t.Errorf("expected zero arrays to be filled, got %+v", dst)
+	}
+
+	dst = identified{ID: uuid{0, 0, 7}}
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.ID != (uuid{0, 0, 7}) {
+		t.Errorf("expected a non-zero array to be kept as a whole, got %v", dst.ID)
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.ID != src.ID {
+		t.Errorf("expected the array to be replaced as a whole, got %v", dst.ID)
+	}
+}
+
+func TestMergeZeroByteArrayDoesNotOverride(t *testing.T) {
+	dst := identified{ID: uuid{1}}
+	if err := mergo.Merge(&dst, identified{Name: "src"}, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.ID != (uuid{1}) {
+		t.Errorf("expected a zero array to be empty and not override dst, got %v", dst.ID)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// boxValue returns a pointer to a copy of src, a value bound to dst, a pointer
+// field, see WithCanonicalizePointers. Numbers are coerced first into the
+// pointed type with WithNumericCoercion. It reports whether src could be
+// boxed.
+func boxValue(dst, src reflect.Value, config *Config) (reflect.Value, bool, error) {
+	elemType := dst.Type().Elem()
+	if config.numericCoercion && src.Type() != elemType && isNumberKind(src.Kind()) && isNumberKind(elemType.Kind()) {
+		coerced, err := coerceField(src, elemType, config)
+		if err != nil {
+			return reflect.Value{}, false, fmt.Errorf("cannot coerce %s field: %w", currentPath(config), err)
+		}
+		src = coerced
+	}
+	if !src.Type().AssignableTo(elemType) {
+		return reflect.Value{}, false, nil
+	}
+	ptr := reflect.New(elemType)
+	ptr.Elem().Set(src)
+	return ptr, true, nil
+}
+
+// deepMergeCanonical merges src into held, the value held by the interface dst,
+// when one of them is a pointer to the type of the other. dst keeps its shape:
+// a held pointer gets src merged into the pointed value, and a held value is
+// merged with the one src points to and stored back. It reports whether the
+// values were merged.
+func deepMergeCanonical(dst, held, src reflect.Value, visited map[visit]bool, depth int, config *Config) (bool, error) {
+	switch {
+	case held.Kind() == reflect.Ptr && !held.IsNil() && held.Type().Elem() == src.Type():
+		return true, deepMerge(held.Elem(), src, visited, depth+1, config)
+	case src.Kind() == reflect.Ptr && !src.IsNil() && src.Type().Elem() == held.Type():
+		if !dst.CanSet() {
+			return false, nil
+		}
+		copied := reflect.New(held.Type()).Elem()
+		copied.Set(held)
+		if err := deepMerge(copied, src.Elem(), visited, depth+1, config); err != nil {
+			return true, err
+		}
+		dst.Set(copied)
+		return true, nil
+	}
+	return false, nil
+}
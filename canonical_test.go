@@ -0,0 +1,92 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type canonicalLimits struct {
+	Max int
+	Min int
+}
+
+type canonicalConfig struct {
+	Port    *int
+	Name    *string
+	Limits  *canonicalLimits
+	Current interface{}
+}
+
+func TestCanonicalizePointersMap(t *testing.T) {
+	src := map[string]interface{}{
+		"port":   8080,
+		"name":   "api",
+		"limits": map[string]interface{}{"max": 10},
+	}
+	dst := canonicalConfig{}
+
+	if err := mergo.Map(&dst, src, mergo.WithCanonicalizePointers); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Port == nil || *dst.Port != 8080 || dst.Name == nil || *dst.Name != "api" {
+		t.Fatalf("expected values to be bound to pointer fields, got %+v", dst)
+	}
+	if dst.Limits == nil || dst.Limits.Max != 10 {
+		t.Errorf("expected the nested map to be bound, got %+v", dst.Limits)
+	}
+
+	port := 80
+	dst = canonicalConfig{Port: &port}
+	if err := mergo.Map(&dst, src, mergo.WithCanonicalizePointers); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Port != &port || port != 80 {
+		t.Errorf("expected non-empty pointed values to be kept, got %d", *dst.Port)
+	}
+	if err := mergo.Map(&dst, src, mergo.WithCanonicalizePointers, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if *dst.Port != 8080 {
+		t.Errorf("expected the pointed value to be overridden, got %d", *dst.Port)
+	}
+
+	if err := mergo.Map(&canonicalConfig{}, src); err == nil {
+		t.Errorf("expected a type mismatch without the option")
+	}
+}
+
+func TestCanonicalizePointersCoercion(t *testing.T) {
+	dst := canonicalConfig{}
+	src := map[string]interface{}{"port": float64(8080)}
+
+	if err := mergo.Map(&dst, src, mergo.WithCanonicalizePointers, mergo.WithNumericCoercion); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Port == nil || *dst.Port != 8080 {
+		t.Errorf("expected the number to be coerced into the pointed type, got %v", dst.Port)
+	}
+}
+
+func TestCanonicalizePointersInterfaces(t *testing.T) {
+	held := &canonicalLimits{Max: 10}
+	dst := canonicalConfig{Current: held}
+	src := canonicalConfig{Current: canonicalLimits{Max: 20, Min: 1}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithCanonicalizePointers); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Current != held || !reflect.DeepEqual(*held, canonicalLimits{Max: 10, Min: 1}) {
+		t.Errorf("expected the value to be merged into the held pointer, got %+v", dst.Current)
+	}
+
+	dst = canonicalConfig{Current: canonicalLimits{Max: 10}}
+	src = canonicalConfig{Current: &canonicalLimits{Max: 20, Min: 1}}
+	if err := mergo.Merge(&dst, src, mergo.WithCanonicalizePointers); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (canonicalLimits{Max: 10, Min: 1}); !reflect.DeepEqual(dst.Current, expected) {
+		t.Errorf("expected the pointed value to be merged into the held value, got %#v", dst.Current)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"strings"
+	"unicode"
+)
+
+// defaultAcronyms are the acronyms known by WithSmartCasing when none is given.
+var defaultAcronyms = []string{
+	"API", "CPU", "DNS", "HTML", "HTTP", "HTTPS", "ID", "IP", "JSON",
+	"SQL", "TCP", "TLS", "UDP", "URI", "URL", "UUID", "XML",
+}
+
+// acronymSet returns the set of the given acronyms in upper case, or of the
+// default ones if none is given.
+func acronymSet(acronyms []string) map[string]bool {
+	if len(acronyms) == 0 {
+		acronyms = defaultAcronyms
+	}
+	set := make(map[string]bool, len(acronyms))
+	for _, acronym := range acronyms {
+		set[strings.ToUpper(acronym)] = true
+	}
+	return set
+}
+
+// nameKey returns the map key for a field or getter name: its initial in lower
+// case or, with WithSmartCasing or WithAcronymAwareKeys, its first word, like
+// httpPort for HTTPPort.
+func nameKey(name string, config *Config) string {
+	acronyms := config.acronyms
+	if acronyms == nil {
+		acronyms = config.keyAcronyms
+	}
+	if acronyms == nil {
+		return changeInitialCase(name, unicode.ToLower)
+	}
+	words := splitName(name, acronyms)
+	if len(words) == 0 {
+		return name
+	}
+	words[0] = strings.ToLower(words[0])
+	return strings.Join(words, "")
+}
+
+// keyName returns the field name for a map key: its initial in upper case or,
+// with WithSmartCasing, every word capitalized and acronyms in upper case,
+// matching both userId and userID with UserID.
+func keyName(key string, config *Config) string {
+	if config.acronyms == nil {
+		return changeInitialCase(key, unicode.ToUpper)
+	}
+	words := splitName(key, config.acronyms)
+	for i, word := range words {
+		if upper := strings.ToUpper(word); config.acronyms[upper] {
+			words[i] = upper
+		} else {
+			words[i] = changeInitialCase(word, unicode.ToUpper)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// splitName splits a camel case name into its words. A run of upper case
+// letters is a word by itself, but for the last letter when followed by a
+// lower case one (HTTPPort is HTTP and Port), and is split further when it is
+// made of known acronyms (APIURL is API and URL).
+func splitName(name string, acronyms map[string]bool) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		switch {
+		case i == len(runes):
+		case unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1]):
+		case unicode.IsUpper(runes[i-1]) && !unicode.IsUpper(runes[i]) && i-1 > start:
+			// The last upper case letter of the run starts the next word.
+			words = append(words, splitAcronyms(string(runes[start:i-1]), acronyms)...)
+			start = i - 1
+			continue
+		default:
+			continue
+		}
+		words = append(words, splitAcronyms(string(runes[start:i]), acronyms)...)
+		start = i
+	}
+	return words
+}
+
+// splitAcronyms splits word into the acronyms it is made of, if it is a
+// concatenation of them, or returns it as is.
+func splitAcronyms(word string, acronyms map[string]bool) []string {
+	if acronyms[word] || strings.ToUpper(word) != word {
+		return []string{word}
+	}
+	for i := len(word) - 1; i > 0; i-- {
+		if !acronyms[word[:i]] {
+			continue
+		}
+		if rest := splitAcronyms(word[i:], acronyms); len(rest) > 1 || acronyms[rest[0]] {
+			return append([]string{word[:i]}, rest...)
+		}
+	}
+	return []string{word}
+}
@@ -0,0 +1,119 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type acronymConfig struct {
+	HTTPPort   int
+	UserID     string
+	APIURL     string
+	BaseURL    string
+	TLSEnabled bool
+	Name       string
+}
+
+func TestSmartCasingStructToMap(t *testing.T) {
+	src := acronymConfig{HTTPPort: 80, UserID: "u", APIURL: "a", BaseURL: "b", TLSEnabled: true, Name: "n"}
+	dst := map[string]interface{}{}
+
+	if err := mergo.Map(&dst, src, mergo.WithSmartCasing()); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"httpPort":   80,
+		"userID":     "u",
+		"apiURL":     "a",
+		"baseURL":    "b",
+		"tlsEnabled": true,
+		"name":       "n",
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestSmartCasingMapToStruct(t *testing.T) {
+	src := map[string]interface{}{
+		"httpPort":   80,
+		"userId":     "u",
+		"apiUrl":     "a",
+		"baseURL":    "b",
+		"tlsEnabled": true,
+		"Name":       "n",
+	}
+	dst := acronymConfig{}
+
+	if err := mergo.Map(&dst, src, mergo.WithSmartCasing()); err != nil {
+		t.Fatal(err)
+	}
+	expected := acronymConfig{HTTPPort: 80, UserID: "u", APIURL: "a", BaseURL: "b", TLSEnabled: true, Name: "n"}
+	if dst != expected {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+}
+
+func TestSmartCasingCustomAcronyms(t *testing.T) {
+	src := struct {
+		GRPCPort int
+		HTTPPort int
+	}{GRPCPort: 1, HTTPPort: 2}
+	dst := map[string]interface{}{}
+
+	if err := mergo.Map(&dst, src, mergo.WithSmartCasing("grpc")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]interface{}{"grpcPort": 1, "httpPort": 2}; !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestSmartCasingDisabled(t *testing.T) {
+	dst := map[string]interface{}{}
+	if err := mergo.Map(&dst, acronymConfig{HTTPPort: 80}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := dst["hTTPPort"]; !ok {
+		t.Errorf("expected the initial to be lowered as before, got %v", dst)
+	}
+}
+
+type acronymPrefixedConfig struct {
+	IDField    string
+	HTTPSProxy string
+	APIURL     string
+	SKUCode    string
+	Name       string
+}
+
+func TestAcronymAwareKeys(t *testing.T) {
+	src := acronymPrefixedConfig{IDField: "i", HTTPSProxy: "h", APIURL: "a", SKUCode: "s", Name: "n"}
+
+	dst := map[string]interface{}{}
+	if err := mergo.Map(&dst, src, mergo.WithAcronymAwareKeys()); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"idField": "i", "httpsProxy": "h", "apiURL": "a", "skuCode": "s", "name": "n"}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+
+	dst = map[string]interface{}{}
+	if err := mergo.Map(&dst, src, mergo.WithAcronymAwareKeys("SKU")); err != nil {
+		t.Fatal(err)
+	}
+	if dst["apiurl"] != "a" || dst["skuCode"] != "s" {
+		t.Errorf("expected only the given acronyms to be split, got %v", dst)
+	}
+
+	out := acronymPrefixedConfig{}
+	if err := mergo.Map(&out, map[string]interface{}{"iDField": "i", "idField": "x"}, mergo.WithAcronymAwareKeys()); err != nil {
+		t.Fatal(err)
+	}
+	if out.IDField != "i" {
+		t.Errorf("expected binding keys to be unchanged, got %+v", out)
+	}
+}
@@ -0,0 +1,40 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+const clearString = "\x00clear"
+
+var clearStrings = []string{clearString}
+
+type clearablePatch struct {
+	Name  string
+	Email string
+	Tags  []string
+	Hosts []string
+}
+
+func TestClearMarker(t *testing.T) {
+	dst := clearablePatch{Name: "dst", Email: "dst@example.com", Tags: []string{"a"}, Hosts: []string{"dst"}}
+	src := clearablePatch{Email: clearString, Tags: clearStrings, Hosts: []string{"src"}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithClearMarker(clearString), mergo.WithClearMarker(clearStrings)); err != nil {
+		t.Fatal(err)
+	}
+	expected := clearablePatch{Name: "dst", Hosts: []string{"src"}}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+
+	dst = clearablePatch{Email: "dst@example.com"}
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Email != clearString {
+		t.Errorf("expected the marker to be set without the option, got %q", dst.Email)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+// copyKey identifies a pointer or map already copied by deepCopy.
+type copyKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// deepCopy returns a copy of src not sharing any storage reachable through
+// pointers, maps, slices and interfaces, except the one referred by unexported
+// fields, which are copied as is. Nil values stay nil and cycles are preserved.
+func deepCopy(src reflect.Value, copies map[copyKey]reflect.Value) reflect.Value {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return src
+		}
+		key := copyKey{src.Pointer(), src.Type()}
+		if c, ok := copies[key]; ok {
+			return c
+		}
+		c := reflect.New(src.Type().Elem())
+		copies[key] = c
+		c.Elem().Set(deepCopy(src.Elem(), copies))
+		return c
+	case reflect.Interface:
+		if src.IsNil() {
+			return src
+		}
+		c := reflect.New(src.Type()).Elem()
+		c.Set(deepCopy(src.Elem(), copies))
+		return c
+	case reflect.Map:
+		if src.IsNil() {
+			return src
+		}
+		key := copyKey{src.Pointer(), src.Type()}
+		if c, ok := copies[key]; ok {
+			return c
+		}
+		c := reflect.MakeMapWithSize(src.Type(), src.Len())
+		copies[key] = c
+		for _, k := range src.MapKeys() {
+			c.SetMapIndex(deepCopy(k, copies), deepCopy(src.MapIndex(k), copies))
+		}
+		return c
+	case reflect.Slice:
+		if src.IsNil() {
+			return src
+		}
+		c := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i, n := 0, src.Len(); i < n; i++ {
+			c.Index(i).Set(deepCopy(src.Index(i), copies))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(src.Type()).Elem()
+		for i, n := 0, src.Len(); i < n; i++ {
+			c.Index(i).Set(deepCopy(src.Index(i), copies))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(src.Type()).Elem()
+		c.Set(src)
+		for i, n := 0, src.NumField(); i < n; i++ {
+			if field := src.Type().Field(i); field.PkgPath == "" {
+				c.Field(i).Set(deepCopy(src.Field(i), copies))
+			}
+		}
+		return c
+	}
+	return src
+}
+
+// clone returns a deep copy of v, see deepCopy.
+func clone(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return deepCopy(reflect.ValueOf(v), make(map[copyKey]reflect.Value)).Interface()
+}
+
+// srcCopy returns src, or a deep copy of it with WithDeepCopy, about to be set
+// into dst.
+func srcCopy(src reflect.Value, config *Config) (reflect.Value, error) {
+	if !config.deepCopy {
+		return src, nil
+	}
+	if err := chargeCopy(config, src); err != nil {
+		return src, err
+	}
+	return deepCopy(src, make(map[copyKey]reflect.Value)), nil
+}
+
+// chargeCopy accounts for the bytes a deep copy of v allocates, see chargeBytes.
+func chargeCopy(config *Config, v reflect.Value) error {
+	if config.maxBytes <= 0 {
+		return nil
+	}
+	return chargeBytes(config, copySize(v, make(map[copyKey]bool)))
+}
+
+// chargeBytes accounts for n bytes about to be allocated, failing with
+// ErrByteBudgetExceeded once the budget set by WithMaxBytes is exceeded.
+func chargeBytes(config *Config, n int64) error {
+	if config.maxBytes <= 0 {
+		return nil
+	}
+	if config.copiedBytes += n; config.copiedBytes > config.maxBytes {
+		return ErrByteBudgetExceeded
+	}
+	return nil
+}
+
+// copySize estimates the bytes deepCopy allocates to copy v: the size of the
+// elements of every pointer, interface, map and slice it reaches, times their
+// count. Storage shared by the copy, like strings, isn't accounted.
+func copySize(v reflect.Value, seen map[copyKey]bool) int64 {
+	var size int64
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || seen[copyKey{v.Pointer(), v.Type()}] {
+			return 0
+		}
+		seen[copyKey{v.Pointer(), v.Type()}] = true
+		size = int64(v.Type().Elem().Size()) + copySize(v.Elem(), seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		size = int64(v.Elem().Type().Size()) + copySize(v.Elem(), seen)
+	case reflect.Map:
+		if v.IsNil() || seen[copyKey{v.Pointer(), v.Type()}] {
+			return 0
+		}
+		seen[copyKey{v.Pointer(), v.Type()}] = true
+		size = int64(v.Len()) * int64(v.Type().Key().Size()+v.Type().Elem().Size())
+		iter := v.MapRange()
+		for iter.Next() {
+			size += copySize(iter.Key(), seen) + copySize(iter.Value(), seen)
+		}
+	case reflect.Slice:
+		size = int64(v.Len()) * int64(v.Type().Elem().Size())
+		fallthrough
+	case reflect.Array:
+		for i, n := 0, v.Len(); i < n; i++ {
+			size += copySize(v.Index(i), seen)
+		}
+	case reflect.Struct:
+		for i, n := 0, v.NumField(); i < n; i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				size += copySize(v.Field(i), seen)
+			}
+		}
+	}
+	return size
+}
@@ -0,0 +1,226 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || isFloatKind(k)
+}
+
+func isScalarKind(k reflect.Kind) bool {
+	return isNumberKind(k) || k == reflect.Bool || k == reflect.String
+}
+
+// converterKey identifies a converter registered by WithConverter.
+type converterKey struct {
+	from, to reflect.Type
+}
+
+// coerceNumber converts v, a number, into a value of typ, another numeric
+// type. It fails if the value doesn't fit in typ or if a float with a
+// fractional part would be truncated into an integer.
+func coerceNumber(v reflect.Value, typ reflect.Type) (reflect.Value, error) {
+	out := reflect.New(typ).Elem()
+	switch k := typ.Kind(); {
+	case isIntKind(k):
+		var i int64
+		switch {
+		case isIntKind(v.Kind()):
+			i = v.Int()
+		case isUintKind(v.Kind()):
+			if v.Uint() > math.MaxInt64 {
+				return out, fmt.Errorf("%v overflows %s", v.Uint(), typ)
+			}
+			i = int64(v.Uint())
+		default:
+			f := v.Float()
+			if f != math.Trunc(f) {
+				return out, fmt.Errorf("%v is not an integer", f)
+			}
+			if f < math.MinInt64 || f >= math.MaxInt64 {
+				return out, fmt.Errorf("%v overflows %s", f, typ)
+			}
+			i = int64(f)
+		}
+		if out.OverflowInt(i) {
+			return out, fmt.Errorf("%v overflows %s", i, typ)
+		}
+		out.SetInt(i)
+	case isUintKind(k):
+		var u uint64
+		switch {
+		case isIntKind(v.Kind()):
+			if v.Int() < 0 {
+				return out, fmt.Errorf("%v overflows %s", v.Int(), typ)
+			}
+			u = uint64(v.Int())
+		case isUintKind(v.Kind()):
+			u = v.Uint()
+		default:
+			f := v.Float()
+			if f != math.Trunc(f) {
+				return out, fmt.Errorf("%v is not an integer", f)
+			}
+			if f < 0 || f >= math.MaxUint64 {
+				return out, fmt.Errorf("%v overflows %s", f, typ)
+			}
+			u = uint64(f)
+		}
+		if out.OverflowUint(u) {
+			return out, fmt.Errorf("%v overflows %s", u, typ)
+		}
+		out.SetUint(u)
+	default:
+		var f float64
+		switch {
+		case isIntKind(v.Kind()):
+			f = float64(v.Int())
+		case isUintKind(v.Kind()):
+			f = float64(v.Uint())
+		default:
+			f = v.Float()
+		}
+		if out.OverflowFloat(f) {
+			return out, fmt.Errorf("%v overflows %s", f, typ)
+		}
+		out.SetFloat(f)
+	}
+	return out, nil
+}
+
+// coerceField converts v into typ like coerceNumber, clamping it first into the
+// range of typ with WithSaturatingNumeric.
+func coerceField(v reflect.Value, typ reflect.Type, config *Config) (reflect.Value, error) {
+	if config.saturatingNumeric {
+		v = saturateNumber(v, typ)
+	}
+	return coerceNumber(v, typ)
+}
+
+// saturateNumber returns v, a number, clamped to the min or max of typ if it
+// doesn't fit in it. Floats with a fractional part are left alone for integer
+// types, so coerceNumber still refuses them.
+func saturateNumber(v reflect.Value, typ reflect.Type) reflect.Value {
+	switch k := typ.Kind(); {
+	case isIntKind(k):
+		max := int64(1)<<(typ.Bits()-1) - 1
+		min := -max - 1
+		switch {
+		case isIntKind(v.Kind()):
+			if v.Int() > max {
+				return reflect.ValueOf(max)
+			} else if v.Int() < min {
+				return reflect.ValueOf(min)
+			}
+		case isUintKind(v.Kind()):
+			if v.Uint() > uint64(max) {
+				return reflect.ValueOf(max)
+			}
+		default:
+			if f := v.Float(); f == math.Trunc(f) {
+				if f >= float64(max) {
+					return reflect.ValueOf(max)
+				} else if f <= float64(min) {
+					return reflect.ValueOf(min)
+				}
+			}
+		}
+	case isUintKind(k):
+		max := uint64(math.MaxUint64) >> (64 - typ.Bits())
+		switch {
+		case isIntKind(v.Kind()):
+			if v.Int() < 0 {
+				return reflect.ValueOf(uint64(0))
+			} else if uint64(v.Int()) > max {
+				return reflect.ValueOf(max)
+			}
+		case isUintKind(v.Kind()):
+			if v.Uint() > max {
+				return reflect.ValueOf(max)
+			}
+		default:
+			if f := v.Float(); f == math.Trunc(f) {
+				if f >= float64(max) {
+					return reflect.ValueOf(max)
+				} else if f < 0 {
+					return reflect.ValueOf(uint64(0))
+				}
+			}
+		}
+	case k == reflect.Float32 && isFloatKind(v.Kind()):
+		if f := v.Float(); f > math.MaxFloat32 {
+			return reflect.ValueOf(float64(math.MaxFloat32))
+		} else if f < -math.MaxFloat32 {
+			return reflect.ValueOf(float64(-math.MaxFloat32))
+		}
+	}
+	return v
+}
+
+var jsonNumberType = reflect.TypeOf(json.Number(""))
+
+// parseJSONNumber parses n, as decoded by a json.Decoder using UseNumber, into
+// a value of typ, a numeric type. It fails if n isn't a number, if it doesn't
+// fit in typ or if it has a fractional part truncated for an integer type.
+func parseJSONNumber(n json.Number, typ reflect.Type) (reflect.Value, error) {
+	var v reflect.Value
+	if k := typ.Kind(); isIntKind(k) {
+		if i, err := n.Int64(); err == nil {
+			v = reflect.ValueOf(i)
+		}
+	} else if isUintKind(k) {
+		if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+			v = reflect.ValueOf(u)
+		}
+	}
+	if !v.IsValid() {
+		// Floats and integers written with an exponent, like 1e3.
+		f, err := n.Float64()
+		if err != nil {
+			return reflect.New(typ).Elem(), fmt.Errorf("%q is not a number", n)
+		}
+		v = reflect.ValueOf(f)
+	}
+	return coerceNumber(v, typ)
+}
+
+// preserveNumericType returns src converted into the type of dst if both hold
+// numbers of different types and src fits exactly in it, or src otherwise.
+func preserveNumericType(dst, src reflect.Value) reflect.Value {
+	dstValue, srcValue := unwrapInterface(dst), unwrapInterface(src)
+	if !dstValue.IsValid() || !srcValue.IsValid() || dstValue.Type() == srcValue.Type() {
+		return src
+	}
+	if !isNumberKind(dstValue.Kind()) || !isNumberKind(srcValue.Kind()) {
+		return src
+	}
+	converted, err := coerceNumber(srcValue, dstValue.Type())
+	if err != nil {
+		return src
+	}
+	if back, err := coerceNumber(converted, srcValue.Type()); err != nil || back.Interface() != srcValue.Interface() {
+		return src
+	}
+	return converted
+}
@@ -0,0 +1,75 @@
+package mergo_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type numericConfig struct {
+	Count   int
+	Small   int8
+	Workers int32
+	Size    uint64
+	Ratio   float32
+	Weight  float64
+}
+
+func TestNumericCoercionFromJSON(t *testing.T) {
+	var src map[string]interface{}
+	data := `{"count": 3, "small": -12, "workers": 8, "size": 1099511627776, "ratio": 0.5, "weight": 2}`
+	if err := json.Unmarshal([]byte(data), &src); err != nil {
+		t.Fatal(err)
+	}
+	dst := numericConfig{}
+
+	if err := mergo.Map(&dst, src, mergo.WithNumericCoercion); err != nil {
+		t.Fatal(err)
+	}
+	expected := numericConfig{Count: 3, Small: -12, Workers: 8, Size: 1 << 40, Ratio: 0.5, Weight: 2}
+	if dst != expected {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestNumericCoercionFromInts(t *testing.T) {
+	dst := numericConfig{}
+	src := map[string]interface{}{"ratio": 2, "size": uint8(3), "count": int64(4)}
+
+	if err := mergo.Map(&dst, src, mergo.WithNumericCoercion); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (numericConfig{Count: 4, Size: 3, Ratio: 2}); dst != expected {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestNumericCoercionErrors(t *testing.T) {
+	testCases := []struct {
+		src map[string]interface{}
+		err string
+	}{
+		{map[string]interface{}{"count": 1.5}, "1.5 is not an integer"},
+		{map[string]interface{}{"small": 300.0}, "300 overflows int8"},
+		{map[string]interface{}{"size": -1.0}, "-1 overflows uint64"},
+		{map[string]interface{}{"size": -1}, "-1 overflows uint64"},
+		{map[string]interface{}{"count": 1e20}, "overflows int"},
+	}
+	for _, tc := range testCases {
+		dst := numericConfig{}
+		err := mergo.Map(&dst, tc.src, mergo.WithNumericCoercion)
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("expected an error containing %q, got %v", tc.err, err)
+		}
+	}
+}
+
+func TestNumericCoercionDisabled(t *testing.T) {
+	dst := numericConfig{}
+	err := mergo.Map(&dst, map[string]interface{}{"count": 3.0})
+	if err == nil || !strings.Contains(err.Error(), "type mismatch") {
+		t.Errorf("expected a type mismatch without the option, got %v", err)
+	}
+}
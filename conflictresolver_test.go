@@ -0,0 +1,69 @@
+package mergo_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/imdario/mergo"
+)
+
+type timestampedRecord struct {
+	ID        string
+	Value     string
+	UpdatedAt time.Time
+}
+
+func newerRecord(dst, src reflect.Value) (reflect.Value, error) {
+	if src.Interface().(timestampedRecord).UpdatedAt.After(dst.Interface().(timestampedRecord).UpdatedAt) {
+		return src, nil
+	}
+	return dst, nil
+}
+
+func TestElementConflictResolverByKey(t *testing.T) {
+	old, now := time.Unix(100, 0), time.Unix(200, 0)
+	dst := struct{ Records []timestampedRecord }{[]timestampedRecord{
+		{ID: "a", Value: "dst", UpdatedAt: old},
+		{ID: "b", Value: "dst", UpdatedAt: now},
+	}}
+	src := struct{ Records []timestampedRecord }{[]timestampedRecord{
+		{ID: "a", Value: "src", UpdatedAt: now},
+		{ID: "b", Value: "src", UpdatedAt: old},
+		{ID: "c", Value: "src", UpdatedAt: old},
+	}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithSliceMergeByKeys("ID"), mergo.WithElementConflictResolver(newerRecord)); err != nil {
+		t.Fatal(err)
+	}
+	expected := []timestampedRecord{
+		{ID: "a", Value: "src", UpdatedAt: now},
+		{ID: "b", Value: "dst", UpdatedAt: now},
+		{ID: "c", Value: "src", UpdatedAt: old},
+	}
+	if !reflect.DeepEqual(dst.Records, expected) {
+		t.Errorf("expected %v, got %v", expected, dst.Records)
+	}
+}
+
+func TestElementConflictResolverByIndex(t *testing.T) {
+	old, now := time.Unix(100, 0), time.Unix(200, 0)
+	dst := struct{ Records []timestampedRecord }{[]timestampedRecord{{Value: "dst", UpdatedAt: now}, {}}}
+	src := struct{ Records []timestampedRecord }{[]timestampedRecord{{Value: "src", UpdatedAt: old}, {Value: "src", UpdatedAt: old}}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithSliceDeepCopy, mergo.WithElementConflictResolver(newerRecord)); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Records[0].Value != "dst" || dst.Records[1].Value != "src" {
+		t.Errorf("expected the newer and the only non-empty records, got %v", dst.Records)
+	}
+
+	errResolve := errors.New("cannot resolve")
+	resolver := mergo.WithElementConflictResolver(func(dst, src reflect.Value) (reflect.Value, error) {
+		return reflect.Value{}, errResolve
+	})
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepCopy, resolver); !errors.Is(err, errResolve) {
+		t.Errorf("expected the resolver error, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package mergo_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/imdario/mergo"
+)
+
+// dateTime is a decoder wrapper, milliseconds since the epoch.
+type dateTime int64
+
+type objectID string
+
+type storedDocument struct {
+	ID        string
+	Version   int64
+	CreatedAt time.Time
+	Owner     objectID
+}
+
+func dateTimeToTime(src reflect.Value) (reflect.Value, error) {
+	ms := src.Int()
+	if ms < 0 {
+		return src, errors.New("negative date")
+	}
+	return reflect.ValueOf(time.UnixMilli(ms).UTC()), nil
+}
+
+func TestConverter(t *testing.T) {
+	src := map[string]interface{}{
+		"iD":        objectID("abc"),
+		"version":   dateTime(3),
+		"createdAt": dateTime(1600000000000),
+		"owner":     "gopher",
+	}
+	dst := storedDocument{}
+
+	err := mergo.Map(&dst, src, mergo.WithConverter(reflect.TypeOf(dateTime(0)), reflect.TypeOf(time.Time{}), dateTimeToTime))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := storedDocument{ID: "abc", Version: 3, CreatedAt: time.UnixMilli(1600000000000).UTC(), Owner: "gopher"}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+}
+
+func TestConverterError(t *testing.T) {
+	dst := storedDocument{}
+	err := mergo.Map(&dst, map[string]interface{}{"createdAt": dateTime(-1)},
+		mergo.WithConverter(reflect.TypeOf(dateTime(0)), reflect.TypeOf(time.Time{}), dateTimeToTime))
+	if err == nil || err.Error() != "cannot convert CreatedAt field: negative date" {
+		t.Errorf("expected the converter error, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type acyclicLeaf struct {
+	Name  string
+	Value int
+}
+
+type acyclicTree struct {
+	Name   string
+	Leaves [8]acyclicLeaf
+	Left   *acyclicTree
+	Right  *acyclicTree
+}
+
+func newAcyclicTree(depth int) *acyclicTree {
+	tree := &acyclicTree{Name: "node"}
+	for i := range tree.Leaves {
+		tree.Leaves[i] = acyclicLeaf{Name: "leaf", Value: i}
+	}
+	if depth > 0 {
+		tree.Left, tree.Right = newAcyclicTree(depth-1), newAcyclicTree(depth-1)
+	}
+	return tree
+}
+
+func TestDisableCycleDetection(t *testing.T) {
+	dst := &acyclicTree{Left: &acyclicTree{}}
+	src := newAcyclicTree(3)
+
+	if err := mergo.Merge(dst, src, mergo.WithDisableCycleDetection); err != nil {
+		t.Fatal(err)
+	}
+	expected := &acyclicTree{Left: &acyclicTree{}}
+	if err := mergo.Merge(expected, src); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected the merge to work as with cycle detection")
+	}
+}
+
+func BenchmarkMergeCycleDetection(b *testing.B) {
+	benchmarkMergeTree(b)
+}
+
+func BenchmarkMergeDisableCycleDetection(b *testing.B) {
+	benchmarkMergeTree(b, mergo.WithDisableCycleDetection)
+}
+
+func benchmarkMergeTree(b *testing.B, opts ...func(*mergo.Config)) {
+	src := newAcyclicTree(6)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst := newAcyclicTree(6)
+		if err := mergo.Merge(dst, src, append(opts, mergo.WithOverride)...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package mergo_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/imdario/mergo"
+)
+
+func TestDebugDumpOnError(t *testing.T) {
+	dst := newSecretsConfig()
+	src := secretsConfig{Nested: secretHolder{D: "bad"}}
+
+	err := mergo.Merge(&dst, src, mergo.WithTransformers(failingSecretTransformer()), mergo.WithDebugDumpOnError)
+	var dumpErr *mergo.DumpError
+	if !errors.As(err, &dumpErr) {
+		t.Fatalf("expected a *mergo.DumpError, got %v", err)
+	}
+	if dumpErr.Path != "Nested.D" || dumpErr.Dst != "d" || dumpErr.Src != "bad" {
+		t.Errorf("expected the failing value to be dumped, got %+v", dumpErr)
+	}
+	if !errors.Is(err, errBadSecret) {
+		t.Error("expected errors.Is to match the original error")
+	}
+	if msg := err.Error(); !strings.Contains(msg, `"Nested.D"`) || !strings.Contains(msg, "src: bad") {
+		t.Errorf("expected the message to contain the context, got %q", msg)
+	}
+}
+
+func TestDebugDumpOnErrorMap(t *testing.T) {
+	dst := struct{ Port int }{}
+	err := mergo.Map(&dst, map[string]interface{}{"port": strings.Repeat("x", 1000)}, mergo.WithDebugDumpOnError)
+	var dumpErr *mergo.DumpError
+	if !errors.As(err, &dumpErr) {
+		t.Fatalf("expected a *mergo.DumpError, got %v", err)
+	}
+	if !strings.Contains(dumpErr.Src, "xxx") || len(dumpErr.Src) > 300 {
+		t.Errorf("expected a truncated dump of src, got %d bytes", len(dumpErr.Src))
+	}
+}
+
+func TestMaxErrorValueLen(t *testing.T) {
+	dst := struct{ Port int }{}
+	src := map[string]interface{}{"port": strings.Repeat("x", 1000)}
+	err := mergo.Map(&dst, src, mergo.WithDebugDumpOnError, mergo.WithMaxErrorValueLen(16))
+	var dumpErr *mergo.DumpError
+	if !errors.As(err, &dumpErr) {
+		t.Fatalf("expected a *mergo.DumpError, got %v", err)
+	}
+	if expected := "map[port:xxxxxxx..."; dumpErr.Src != expected {
+		t.Errorf("expected src to be truncated to %q, got %q", expected, dumpErr.Src)
+	}
+	if len(err.Error()) > 128 {
+		t.Errorf("expected a short message, got %q", err.Error())
+	}
+}
+
+func TestMaxErrorValueLenUTF8(t *testing.T) {
+	dst := struct{ Port int }{}
+	src := map[string]interface{}{"port": strings.Repeat("é世", 100)}
+	for maxLen := 8; maxLen < 24; maxLen++ {
+		err := mergo.Map(&dst, src, mergo.WithDebugDumpOnError, mergo.WithMaxErrorValueLen(maxLen))
+		var dumpErr *mergo.DumpError
+		if !errors.As(err, &dumpErr) {
+			t.Fatalf("expected a *mergo.DumpError, got %v", err)
+		}
+		if !utf8.ValidString(dumpErr.Src) || !utf8.ValidString(err.Error()) {
+			t.Errorf("max %d: expected the dump to be valid UTF-8, got %q", maxLen, dumpErr.Src)
+		}
+		if len(dumpErr.Src) > maxLen+len("...") {
+			t.Errorf("max %d: expected src to be truncated, got %q", maxLen, dumpErr.Src)
+		}
+	}
+}
+
+func TestDebugDumpOnErrorDisabled(t *testing.T) {
+	dst := newSecretsConfig()
+	src := secretsConfig{A: "bad"}
+	err := mergo.Merge(&dst, src, mergo.WithTransformers(failingSecretTransformer()))
+	if err != errBadSecret {
+		t.Errorf("expected the error to be returned as is, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type deepCopyNode struct {
+	Name   string
+	Labels map[string]string
+	Next   *deepCopyNode
+}
+
+type deepCopyConfig struct {
+	Root   *deepCopyNode
+	Nodes  []*deepCopyNode
+	Tags   map[string][]string
+	Extra  interface{}
+	Absent *deepCopyNode
+	Nil    map[string]string
+}
+
+func newDeepCopyConfig() deepCopyConfig {
+	root := &deepCopyNode{Name: "root", Labels: map[string]string{"env": "prod"}}
+	root.Next = root
+	return deepCopyConfig{
+		Root:  root,
+		Nodes: []*deepCopyNode{{Name: "leaf", Labels: map[string]string{"tier": "db"}}},
+		Tags:  map[string][]string{"owners": {"ana"}},
+		Extra: map[string]int{"retries": 3},
+	}
+}
+
+func TestMergeWithDeepCopy(t *testing.T) {
+	src := newDeepCopyConfig()
+	var dst deepCopyConfig
+	if err := mergo.Merge(&dst, src, mergo.WithDeepCopy); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Root == src.Root || dst.Root.Next != dst.Root {
+		t.Errorf("expected the root cycle to be copied, got %p (src %p)", dst.Root, src.Root)
+	}
+	dst.Root.Labels["env"] = "dev"
+	dst.Root.Name = "changed"
+	dst.Nodes[0].Labels["tier"] = "cache"
+	dst.Tags["owners"][0] = "bob"
+	dst.Extra.(map[string]int)["retries"] = 0
+	if !reflect.DeepEqual(src, newDeepCopyConfig()) {
+		t.Errorf("expected src to be unchanged, got %+v", src)
+	}
+	if dst.Absent != nil || dst.Nil != nil {
+		t.Errorf("expected nil values to stay nil, got %v and %v", dst.Absent, dst.Nil)
+	}
+}
+
+func TestMergeWithoutDeepCopy(t *testing.T) {
+	src := newDeepCopyConfig()
+	var dst deepCopyConfig
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Root != src.Root || dst.Nodes[0] != src.Nodes[0] || &dst.Tags["owners"][0] != &src.Tags["owners"][0] {
+		t.Errorf("expected src storage to be shared by default")
+	}
+}
+
+func TestMergeWithDeepCopyByKey(t *testing.T) {
+	src := deepCopyConfig{Nodes: []*deepCopyNode{{Name: "leaf", Labels: map[string]string{"tier": "db"}}}}
+	dst := deepCopyConfig{Nodes: []*deepCopyNode{{Name: "root"}}}
+	if err := mergo.Merge(&dst, src, mergo.WithDeepCopy, mergo.WithSliceDeepMergeByKey("Name")); err != nil {
+		t.Fatal(err)
+	}
+	if len(dst.Nodes) != 2 || dst.Nodes[1] == src.Nodes[0] {
+		t.Fatalf("expected leaf to be appended as a copy, got %v", dst.Nodes)
+	}
+	dst.Nodes[1].Labels["tier"] = "cache"
+	dst.Nodes[1].Name = "changed"
+	if src.Nodes[0].Name != "leaf" || src.Nodes[0].Labels["tier"] != "db" {
+		t.Errorf("expected src to be unchanged, got %+v", src.Nodes[0])
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// applyDefaults sets the defaults given by WithDefaultsForEmpty into the values
+// of dst still empty after the merge.
+func applyDefaults(dst reflect.Value, config *Config) error {
+	paths := make([]string, 0, len(config.defaults))
+	for path := range config.defaults {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := applyDefault(dst, parsePath(path), config.defaults[path]); err != nil {
+			return fmt.Errorf("cannot apply default to %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// applyDefault sets def into the value found following path from v if it is
+// empty, allocating the nil pointers and missing map keys on the way.
+func applyDefault(v reflect.Value, path []pathElement, def interface{}) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			if !v.CanSet() {
+				return nil
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if len(path) == 0 {
+		if !isEmptyValue(v) && !(v.Kind() == reflect.Struct && isEmptyStruct(v)) {
+			return nil
+		}
+		value := reflect.ValueOf(def)
+		if !value.IsValid() || !value.Type().AssignableTo(v.Type()) {
+			return fmt.Errorf("%w: %T into %s", ErrDifferentArgumentsTypes, def, v.Type())
+		}
+		if v.CanSet() {
+			v.Set(value)
+		}
+		return nil
+	}
+	e := path[0]
+	if e.field != "" {
+		if v.Kind() != reflect.Struct {
+			return fmt.Errorf("%w: %s has no %s field", ErrNotSupported, v.Type(), e.field)
+		}
+		field := fieldByName(v, e.field)
+		if !field.IsValid() {
+			return fmt.Errorf("%w: %s has no %s field", ErrNotSupported, v.Type(), e.field)
+		}
+		if isReadOnlyByName(v.Type(), e.field) {
+			return nil
+		}
+		return applyDefault(field, path[1:], def)
+	}
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("%w: %s has no string keys", ErrNotSupported, v.Type())
+	}
+	if v.IsNil() {
+		if !v.CanSet() {
+			return nil
+		}
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	key := e.key.Convert(v.Type().Key())
+	// Map values aren't addressable, so set a copy and store it back.
+	elem := reflect.New(v.Type().Elem()).Elem()
+	if current := v.MapIndex(key); current.IsValid() {
+		elem.Set(current)
+	}
+	if err := applyDefault(elem, path[1:], def); err != nil {
+		return err
+	}
+	v.SetMapIndex(key, elem)
+	return nil
+}
+
+// parsePath parses a path rendered by currentPath, like Spec.Labels[app].Name,
+// with string map keys.
+func parsePath(path string) []pathElement {
+	var elements []pathElement
+	for path != "" {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				end = len(path)
+			}
+			elements = append(elements, pathElement{key: reflect.ValueOf(path[1:end])})
+			if end < len(path) {
+				end++
+			}
+			path = path[end:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			elements = append(elements, pathElement{field: path[:end]})
+			path = path[end:]
+		}
+	}
+	return elements
+}
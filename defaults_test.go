@@ -0,0 +1,68 @@
+package mergo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type defaultsTLS struct {
+	Cert string
+}
+
+type defaultsConfig struct {
+	Name   string
+	Port   int
+	TLS    *defaultsTLS
+	Labels map[string]string
+}
+
+func TestDefaultsForEmpty(t *testing.T) {
+	dst := defaultsConfig{Name: "dst"}
+	src := defaultsConfig{Labels: map[string]string{"app": "web"}}
+	defaults := map[string]interface{}{
+		"Name":         "default",
+		"Port":         8080,
+		"TLS.Cert":     "default.pem",
+		"Labels[app]":  "default",
+		"Labels[tier]": "backend",
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithDefaultsForEmpty(defaults)); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "dst" || dst.Labels["app"] != "web" {
+		t.Errorf("expected merged values to be kept, got %+v", dst)
+	}
+	if dst.Port != 8080 || dst.TLS == nil || dst.TLS.Cert != "default.pem" || dst.Labels["tier"] != "backend" {
+		t.Errorf("expected defaults to fill empty values, got %+v", dst)
+	}
+}
+
+func TestDefaultsForEmptyMap(t *testing.T) {
+	var dst defaultsConfig
+	src := map[string]interface{}{"name": "src"}
+
+	if err := mergo.Map(&dst, src, mergo.WithDefaultsForEmpty(map[string]interface{}{"Port": 80})); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "src" || dst.Port != 80 {
+		t.Errorf("expected name to be mapped and port to be defaulted, got %+v", dst)
+	}
+}
+
+func TestDefaultsForEmptyErrors(t *testing.T) {
+	for path, value := range map[string]interface{}{"Port": "80", "Missing": 1} {
+		var dst defaultsConfig
+		err := mergo.Merge(&dst, defaultsConfig{}, mergo.WithDefaultsForEmpty(map[string]interface{}{path: value}))
+		if err == nil {
+			t.Errorf("expected an error for the %s default", path)
+		}
+	}
+	var dst defaultsConfig
+	err := mergo.Merge(&dst, defaultsConfig{}, mergo.WithDefaultsForEmpty(map[string]interface{}{"Port": "80"}))
+	if !errors.Is(err, mergo.ErrDifferentArgumentsTypes) {
+		t.Errorf("expected ErrDifferentArgumentsTypes, got %v", err)
+	}
+}
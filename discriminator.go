@@ -0,0 +1,51 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// mapDiscriminated binds src, a map naming its variant under the discriminator
+// key, to dst, an interface field, see WithDiscriminator. It reports whether
+// src was bound.
+func mapDiscriminated(dst reflect.Value, src interface{}, visited map[visit]bool, depth int, config *Config) (bool, error) {
+	srcMap, ok := src.(map[string]interface{})
+	if !ok || dst.Kind() != reflect.Interface {
+		return false, nil
+	}
+	name, ok := srcMap[config.discriminator].(string)
+	if !ok {
+		return false, nil
+	}
+	factory, ok := config.discriminatorRegistry[name]
+	if !ok {
+		return true, fmt.Errorf("%w %q at %s", ErrUnknownDiscriminator, name, currentPath(config))
+	}
+	value := reflect.ValueOf(factory())
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct || !value.Type().AssignableTo(dst.Type()) {
+		return true, fmt.Errorf("%w: %s variant %s doesn't implement %s", ErrDifferentArgumentsTypes, name, value.Type(), dst.Type())
+	}
+	if !dst.IsNil() {
+		if current := dst.Elem(); current.Type() == value.Type() {
+			// Same variant, so merge into the value already there.
+			value = current
+		} else if !config.Overwrite {
+			return true, nil
+		}
+	}
+	fields := make(map[string]interface{}, len(srcMap)-1)
+	for key, v := range srcMap {
+		if key != config.discriminator {
+			fields[key] = v
+		}
+	}
+	if err := deepMap(value, reflect.ValueOf(fields), visited, depth+1, config); err != nil {
+		return true, err
+	}
+	dst.Set(value)
+	return true, nil
+}
@@ -0,0 +1,102 @@
+package mergo_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type listener interface {
+	Addr() string
+}
+
+type tlsListener struct {
+	Host string
+	Cert string
+}
+
+func (l *tlsListener) Addr() string { return l.Host }
+
+type unixListener struct {
+	Path string
+}
+
+func (l *unixListener) Addr() string { return l.Path }
+
+type server struct {
+	Name     string
+	Listener listener
+}
+
+var listeners = map[string]func() interface{}{
+	"tls":  func() interface{} { return &tlsListener{} },
+	"unix": func() interface{} { return &unixListener{} },
+}
+
+func TestDiscriminator(t *testing.T) {
+	src := map[string]interface{}{
+		"name":     "api",
+		"listener": map[string]interface{}{"type": "tls", "host": "example.com", "cert": "cert.pem"},
+	}
+	dst := server{}
+	if err := mergo.Map(&dst, src, mergo.WithDiscriminator("type", listeners)); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (&tlsListener{Host: "example.com", Cert: "cert.pem"}); !reflect.DeepEqual(dst.Listener, expected) {
+		t.Errorf("expected %#v, got %#v", expected, dst.Listener)
+	}
+
+	src = map[string]interface{}{
+		"listener": map[string]interface{}{"type": "unix", "path": "/run/api.sock"},
+	}
+	dst = server{}
+	if err := mergo.Map(&dst, src, mergo.WithDiscriminator("type", listeners)); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (&unixListener{Path: "/run/api.sock"}); !reflect.DeepEqual(dst.Listener, expected) {
+		t.Errorf("expected %#v, got %#v", expected, dst.Listener)
+	}
+}
+
+func TestDiscriminatorExistingVariant(t *testing.T) {
+	current := &tlsListener{Host: "example.com"}
+	dst := server{Listener: current}
+	src := map[string]interface{}{
+		"listener": map[string]interface{}{"type": "tls", "host": "other.com", "cert": "cert.pem"},
+	}
+	if err := mergo.Map(&dst, src, mergo.WithDiscriminator("type", listeners)); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Listener != current || current.Host != "example.com" || current.Cert != "cert.pem" {
+		t.Errorf("expected the same variant to be merged into, got %#v", dst.Listener)
+	}
+
+	src = map[string]interface{}{
+		"listener": map[string]interface{}{"type": "unix", "path": "/run/api.sock"},
+	}
+	if err := mergo.Map(&dst, src, mergo.WithDiscriminator("type", listeners)); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Listener != current {
+		t.Errorf("expected a different variant to be kept without override, got %#v", dst.Listener)
+	}
+	if err := mergo.Map(&dst, src, mergo.WithDiscriminator("type", listeners), mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (&unixListener{Path: "/run/api.sock"}); !reflect.DeepEqual(dst.Listener, expected) {
+		t.Errorf("expected %#v with override, got %#v", expected, dst.Listener)
+	}
+}
+
+func TestDiscriminatorUnknown(t *testing.T) {
+	src := map[string]interface{}{
+		"listener": map[string]interface{}{"type": "udp"},
+	}
+	dst := server{}
+	err := mergo.Map(&dst, src, mergo.WithDiscriminator("type", listeners))
+	if !errors.Is(err, mergo.ErrUnknownDiscriminator) {
+		t.Fatalf("expected %v, got %v", mergo.ErrUnknownDiscriminator, err)
+	}
+}
@@ -6,7 +6,7 @@
 /*
 A helper to merge structs and maps in Golang. Useful for configuration default values, avoiding messy if-statements.
 
-Mergo merges same-type structs and maps by setting default values in zero-value fields. Mergo won't merge unexported (private) fields. It will do recursively any exported one. It also won't merge structs inside maps (because they are not addressable using Go reflection).
+Mergo merges same-type structs and maps by setting default values in zero-value fields. Mergo won't merge unexported (private) fields. It will do recursively any exported one. Structs inside maps are merged into a copy which is stored back, as they are not addressable using Go reflection.
 
 Status
 
@@ -33,7 +33,7 @@ Do your usual installation procedure:
 
 Usage
 
-You can only merge same-type structs with exported fields initialized as zero value of their type and same-types maps. Mergo won't merge unexported (private) fields but will do recursively any exported one. It won't merge empty structs value as they are zero values too. Also, maps will be merged recursively except for structs inside maps (because they are not addressable using Go reflection).
+You can only merge same-type structs with exported fields initialized as zero value of their type and same-types maps. Mergo won't merge unexported (private) fields but will do recursively any exported one. It won't merge empty structs value as they are zero values too. Also, maps will be merged recursively, including the structs inside them.
 
 	if err := mergo.Merge(&dst, src); err != nil {
 		// ...
@@ -51,7 +51,7 @@ Additionally, you can map a map[string]interface{} to a struct (and otherwise, f
 		// ...
 	}
 
-Warning: if you map a struct to map, it won't do it recursively. Don't expect Mergo to map struct members of your struct as map[string]interface{}. They will be just assigned as values.
+When mapping a struct to a map[string]interface{}, struct members are mapped recursively as map[string]interface{} too, merged into the ones already in the map, and slices of structs as []interface{} of them. Nil pointers are skipped.
 
 Here is a nice example:
 
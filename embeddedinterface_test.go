@@ -0,0 +1,72 @@
+package mergo_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type staticReader struct {
+	Data string
+	Size int
+}
+
+func (r staticReader) Read(p []byte) (int, error) {
+	return copy(p, r.Data), io.EOF
+}
+
+type embeddedReader struct {
+	io.Reader
+	Name string
+}
+
+func TestMergeEmbeddedInterface(t *testing.T) {
+	src := embeddedReader{Reader: strings.NewReader("src"), Name: "src"}
+	dst := embeddedReader{}
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Reader != src.Reader || dst.Name != "src" {
+		t.Errorf("expected the nil embedded interface to be assigned, got %+v", dst)
+	}
+}
+
+func TestMergeEmbeddedInterfaceSameType(t *testing.T) {
+	dst := embeddedReader{Reader: staticReader{Data: "dst"}}
+	src := embeddedReader{Reader: staticReader{Data: "src", Size: 3}}
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (staticReader{Data: "dst", Size: 3}); dst.Reader != expected {
+		t.Errorf("expected the concrete values to be merged into %+v, got %+v", expected, dst.Reader)
+	}
+
+	dst = embeddedReader{Reader: &staticReader{Data: "dst"}}
+	src = embeddedReader{Reader: &staticReader{Size: 3}}
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (staticReader{Data: "dst", Size: 3}); *dst.Reader.(*staticReader) != expected {
+		t.Errorf("expected the pointed values to be merged into %+v, got %+v", expected, dst.Reader)
+	}
+}
+
+func TestMergeEmbeddedInterfaceDifferentTypes(t *testing.T) {
+	reader := strings.NewReader("dst")
+	dst := embeddedReader{Reader: reader}
+	src := embeddedReader{Reader: staticReader{Data: "src"}}
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Reader != reader {
+		t.Errorf("expected dst to be kept, got %+v", dst.Reader)
+	}
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Reader != src.Reader {
+		t.Errorf("expected src to override dst, got %+v", dst.Reader)
+	}
+}
@@ -0,0 +1,26 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func TestMergeFalseBoolIsEmpty(t *testing.T) {
+	type flags struct {
+		Enabled bool
+		Verbose bool
+	}
+
+	dst := flags{Enabled: true}
+	src := flags{Verbose: true}
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.Enabled {
+		t.Error("a false src overwrote a true dst, expected it to be treated as empty")
+	}
+	if !dst.Verbose {
+		t.Error("a true src didn't fill a false dst")
+	}
+}
@@ -0,0 +1,39 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type sparseHosts struct {
+	Hosts []string
+}
+
+func TestTreatEmptyStringSliceElementsAsAbsent(t *testing.T) {
+	dst := sparseHosts{Hosts: []string{"a", "b", "c", "d"}}
+	src := sparseHosts{Hosts: []string{"", "B", "", "D", "E"}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepCopy, mergo.WithTreatEmptyStringSliceElementsAsAbsent); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a", "B", "c", "D"}; !reflect.DeepEqual(dst.Hosts, expected) {
+		t.Errorf("expected %v, got %v", expected, dst.Hosts)
+	}
+	if expected := []string{"", "B", "", "D", "E"}; !reflect.DeepEqual(src.Hosts, expected) {
+		t.Errorf("expected src not to change, got %v", src.Hosts)
+	}
+}
+
+func TestTreatEmptyStringSliceElementsAsAbsentMap(t *testing.T) {
+	dst := map[string]interface{}{"hosts": []string{"a", "b", "c"}}
+	src := map[string]interface{}{"hosts": []string{"", "", "C"}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepCopy, mergo.WithTreatEmptyStringSliceElementsAsAbsent); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a", "b", "C"}; !reflect.DeepEqual(dst["hosts"], expected) {
+		t.Errorf("expected %v, got %v", expected, dst["hosts"])
+	}
+}
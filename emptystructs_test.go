@@ -0,0 +1,77 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type emptyWindow struct {
+	start, end int
+}
+
+type emptyDeep struct {
+	Level int
+}
+
+type emptyInner struct {
+	Name string
+	Deep emptyDeep
+}
+
+type emptyStructsConfig struct {
+	Window emptyWindow
+	Inner  emptyInner
+}
+
+func TestSkipEmptyStructsWithOverride(t *testing.T) {
+	dst := emptyStructsConfig{Window: emptyWindow{1, 2}}
+
+	if err := mergo.Merge(&dst, emptyStructsConfig{}, mergo.WithOverride, mergo.WithSkipEmptyStructs); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Window != (emptyWindow{1, 2}) {
+		t.Errorf("expected window to be kept, got %v", dst.Window)
+	}
+
+	if err := mergo.Merge(&dst, emptyStructsConfig{}, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Window != (emptyWindow{}) {
+		t.Errorf("expected window to be overwritten without the option, got %v", dst.Window)
+	}
+}
+
+func TestSkipEmptyStructsNested(t *testing.T) {
+	dst := emptyStructsConfig{Inner: emptyInner{Name: "dst", Deep: emptyDeep{Level: 3}}}
+	src := emptyStructsConfig{Window: emptyWindow{5, 6}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverwriteWithEmptyValue, mergo.WithSkipEmptyStructs); err != nil {
+		t.Fatal(err)
+	}
+	expected := emptyStructsConfig{Window: emptyWindow{5, 6}, Inner: emptyInner{Name: "dst", Deep: emptyDeep{Level: 3}}}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+
+	src = emptyStructsConfig{Inner: emptyInner{Deep: emptyDeep{Level: 4}}}
+	if err := mergo.Merge(&dst, src, mergo.WithOverwriteWithEmptyValue, mergo.WithSkipEmptyStructs); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Inner.Deep.Level != 4 || dst.Inner.Name != "" {
+		t.Errorf("expected non-empty nested struct to be merged, got %v", dst.Inner)
+	}
+}
+
+func TestSkipEmptyStructsInMaps(t *testing.T) {
+	dst := map[string]interface{}{"window": emptyWindow{1, 2}}
+	src := map[string]interface{}{"window": emptyWindow{}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithSkipEmptyStructs); err != nil {
+		t.Fatal(err)
+	}
+	if dst["window"] != (emptyWindow{1, 2}) {
+		t.Errorf("expected window to be kept, got %v", dst["window"])
+	}
+}
@@ -0,0 +1,84 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type equalSubtreeSection struct {
+	Name    string
+	Values  []int
+	Options map[string]string
+}
+
+type equalSubtreeGroup struct {
+	A, B, C, D equalSubtreeSection
+}
+
+type equalSubtreeConfig struct {
+	First, Second equalSubtreeGroup
+	Changed       equalSubtreeSection
+}
+
+func newEqualSubtreeConfig() equalSubtreeConfig {
+	section := func() equalSubtreeSection {
+		return equalSubtreeSection{Name: "section", Values: []int{1, 2, 3}, Options: map[string]string{"a": "b", "c": "d"}}
+	}
+	group := func() equalSubtreeGroup {
+		return equalSubtreeGroup{section(), section(), section(), section()}
+	}
+	return equalSubtreeConfig{First: group(), Second: group(), Changed: equalSubtreeSection{Name: "dst"}}
+}
+
+type countingTransformer struct {
+	calls int
+}
+
+func (c *countingTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(equalSubtreeSection{}) {
+		return nil
+	}
+	return func(dst, src reflect.Value) error {
+		c.calls++
+		dst.Set(src)
+		return nil
+	}
+}
+
+func TestSkipEqualSubtrees(t *testing.T) {
+	dst, src := newEqualSubtreeConfig(), newEqualSubtreeConfig()
+	src.Changed = equalSubtreeSection{Name: "src", Values: []int{4}}
+	transformer := &countingTransformer{}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithSkipEqualSubtrees, mergo.WithTransformers(transformer)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst.Changed, src.Changed) {
+		t.Errorf("expected the changed subtree to be merged, got %+v", dst.Changed)
+	}
+	if transformer.calls != 1 {
+		t.Errorf("expected only the changed subtree to be transformed, got %d calls", transformer.calls)
+	}
+}
+
+func BenchmarkMergeEqualSubtrees(b *testing.B) {
+	benchmarkMergeEqualSubtrees(b)
+}
+
+func BenchmarkMergeSkipEqualSubtrees(b *testing.B) {
+	benchmarkMergeEqualSubtrees(b, mergo.WithSkipEqualSubtrees)
+}
+
+func benchmarkMergeEqualSubtrees(b *testing.B, opts ...func(*mergo.Config)) {
+	dst, src := newEqualSubtreeConfig(), newEqualSubtreeConfig()
+	src.Changed.Name = "src"
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := mergo.Merge(&dst, src, append(opts, mergo.WithOverride)...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
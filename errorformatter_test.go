@@ -0,0 +1,41 @@
+package mergo_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func frenchMismatch(err *mergo.TypeMismatchError) string {
+	return fmt.Sprintf("types incompatibles pour %s : %s au lieu de %s", err.Path, err.Src, err.Dst)
+}
+
+func TestErrorFormatter(t *testing.T) {
+	dst := struct{ Port int }{}
+	src := map[string]interface{}{"port": "80"}
+
+	err := mergo.Map(&dst, src, mergo.WithErrorFormatter(frenchMismatch))
+	if expected := "types incompatibles pour Port : string au lieu de int"; err == nil || err.Error() != expected {
+		t.Fatalf("expected %q, got %v", expected, err)
+	}
+	var mismatch *mergo.TypeMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected a *TypeMismatchError, got %T", err)
+	}
+	if mismatch.Path != "Port" || mismatch.Dst.Kind().String() != "int" || mismatch.Src.Kind().String() != "string" {
+		t.Errorf("unexpected mismatch %+v", mismatch)
+	}
+}
+
+func TestErrorFormatterSentinel(t *testing.T) {
+	dst := struct{ Port int }{}
+	err := mergo.Merge(&dst, struct{ Name string }{}, mergo.WithErrorFormatter(frenchMismatch))
+	if !errors.Is(err, mergo.ErrDifferentArgumentsTypes) {
+		t.Fatalf("expected %v to be wrapped, got %v", mergo.ErrDifferentArgumentsTypes, err)
+	}
+	if expected := "types incompatibles pour  : struct { Name string } au lieu de struct { Port int }"; err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
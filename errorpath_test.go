@@ -0,0 +1,84 @@
+package mergo_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type pathListener struct {
+	Port int
+}
+
+type pathSpec struct {
+	Name      string
+	Timeout   int
+	Listeners map[string]interface{}
+}
+
+func TestTypeMismatchPath(t *testing.T) {
+	var dst struct{ Spec pathSpec }
+	src := map[string]interface{}{"spec": map[string]interface{}{"timeout": "soon"}}
+	err := mergo.Map(&dst, src)
+	var mismatch *mergo.TypeMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected a *TypeMismatchError, got %T: %v", err, err)
+	}
+	if mismatch.Path != "Spec.Timeout" || mismatch.SrcKind != reflect.String || mismatch.DstKind != reflect.Int {
+		t.Errorf("expected the mismatch at Spec.Timeout from string to int, got %q from %s to %s", mismatch.Path, mismatch.SrcKind, mismatch.DstKind)
+	}
+	if err.Error() != "cannot merge Spec.Timeout: found string, expected int" {
+		t.Errorf("expected the message to tell the path and types, got %q", err)
+	}
+	if !errors.Is(err, mergo.ErrDifferentArgumentsTypes) {
+		t.Errorf("expected %v to be wrapped, got %v", mergo.ErrDifferentArgumentsTypes, err)
+	}
+}
+
+func TestTypeMismatchPathThroughMaps(t *testing.T) {
+	dst := map[string]interface{}{"listeners": map[string]pathListener{"http": {Port: 80}}}
+	src := map[string]interface{}{"listeners": map[string]interface{}{"http": "80"}}
+	err := mergo.Merge(&dst, src)
+	if expected := "cannot merge [listeners]: found map[string]interface {}, expected map[string]mergo_test.pathListener"; err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+	if !errors.Is(err, mergo.ErrDifferentArgumentsTypes) {
+		t.Errorf("expected %v to be wrapped, got %v", mergo.ErrDifferentArgumentsTypes, err)
+	}
+}
+
+func TestTypeMismatchTopLevel(t *testing.T) {
+	dst := pathListener{}
+	if err := mergo.Merge(&dst, pathSpec{}); err != mergo.ErrDifferentArgumentsTypes {
+		t.Errorf("expected %v, got %v", mergo.ErrDifferentArgumentsTypes, err)
+	}
+}
+
+func TestWithErrorAccumulation(t *testing.T) {
+	var dst struct {
+		Spec pathSpec
+		Port int
+	}
+	src := map[string]interface{}{
+		"spec": map[string]interface{}{"name": 1, "timeout": "soon"},
+		"port": "http",
+	}
+	err := mergo.Map(&dst, src, mergo.WithErrorAccumulation, mergo.WithDeterministicOrder)
+	var errs mergo.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected mergo.Errors, got %T: %v", err, err)
+	}
+	var paths []string
+	for _, err := range errs {
+		var mismatch *mergo.TypeMismatchError
+		if !errors.As(err, &mismatch) {
+			t.Fatalf("expected a *TypeMismatchError, got %T: %v", err, err)
+		}
+		paths = append(paths, mismatch.Path)
+	}
+	if expected := []string{"Port", "Spec.Name", "Spec.Timeout"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected mismatches at %v, got %v", expected, paths)
+	}
+}
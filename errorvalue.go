@@ -0,0 +1,36 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// isErrorValue reports whether v holds an error: its type implements error, or
+// it is an interface holding one.
+func isErrorValue(v reflect.Value) bool {
+	if traitsOf(v.Type()).error {
+		return true
+	}
+	return v.Kind() == reflect.Interface && !v.IsNil() && traitsOf(v.Elem().Type()).error
+}
+
+// mergeError merges src into dst, an error, as a whole: merging errors field by
+// field would mix their messages and chains, and modify errors that may be
+// shared, like sentinels. dst is replaced when empty or with overwrite, so
+// errors.Is and errors.As see the chain of src. It reports whether src could
+// be assigned to dst.
+func mergeError(dst, src reflect.Value, overwrite, overwriteWithEmptySrc bool, config *Config) bool {
+	if !src.Type().AssignableTo(dst.Type()) {
+		return false
+	}
+	if dst.CanSet() && (isEmptyElement(dst) || overwrite) && (!isEmptyElement(src) || overwriteWithEmptySrc) {
+		traceDecision(config, TraceSet, dst, src)
+		dst.Set(src)
+	} else {
+		traceDecision(config, TraceKeep, dst, dst)
+	}
+	return true
+}
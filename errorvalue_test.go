@@ -0,0 +1,70 @@
+package mergo_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+var (
+	errTimeout = errors.New("timeout")
+	errRefused = errors.New("connection refused")
+)
+
+type opError struct {
+	Op  string
+	Err error
+}
+
+func (e *opError) Error() string { return e.Op + ": " + e.Err.Error() }
+func (e *opError) Unwrap() error { return e.Err }
+
+type probeResult struct {
+	Err     error
+	LastErr *opError
+}
+
+func TestMergeErrorsKeepChain(t *testing.T) {
+	dst := probeResult{Err: fmt.Errorf("probe: %w", errRefused), LastErr: &opError{Op: "dial", Err: errRefused}}
+	src := probeResult{Err: fmt.Errorf("probe: %w", &opError{Op: "read", Err: errTimeout}), LastErr: &opError{Op: "read", Err: errTimeout}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(dst.Err, errTimeout) || errors.Is(dst.Err, errRefused) {
+		t.Errorf("expected the src chain, got %v", dst.Err)
+	}
+	var op *opError
+	if !errors.As(dst.Err, &op) || op.Op != "read" {
+		t.Errorf("expected errors.As to find the src opError, got %v", op)
+	}
+	if dst.LastErr != src.LastErr {
+		t.Errorf("expected the src error to be assigned as a whole, got %v", dst.LastErr)
+	}
+}
+
+func TestMergeErrorsNotMergedFieldByField(t *testing.T) {
+	shared := &opError{Op: "dial"}
+	dst := probeResult{Err: shared, LastErr: shared}
+	src := probeResult{Err: &opError{Op: "read", Err: errTimeout}, LastErr: &opError{Op: "read", Err: errTimeout}}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if shared.Err != nil {
+		t.Errorf("expected the dst error to be left alone, got %v", shared.Err)
+	}
+	if errors.Is(dst.Err, errTimeout) || dst.LastErr != shared {
+		t.Errorf("expected dst errors to be kept, got %v and %v", dst.Err, dst.LastErr)
+	}
+
+	dst = probeResult{}
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(dst.Err, errTimeout) || !errors.Is(dst.LastErr, errTimeout) {
+		t.Errorf("expected empty dst errors to take the src ones, got %v and %v", dst.Err, dst.LastErr)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"reflect"
+	"sync"
+	"unicode"
+)
+
+// fieldIndexes caches the index of every field of a struct type reachable by
+// name, including the promoted ones, by reflect.Type.
+var fieldIndexes sync.Map
+
+// fieldByName returns the field of the struct v with the given name, like
+// v.FieldByName does, without walking the fields of its type on every call.
+func fieldByName(v reflect.Value, name string) reflect.Value {
+	index, ok := fieldIndexesOf(v.Type())[name]
+	if !ok {
+		return reflect.Value{}
+	}
+	return v.FieldByIndex(index)
+}
+
+func fieldIndexesOf(typ reflect.Type) map[string][]int {
+	if indexes, ok := fieldIndexes.Load(typ); ok {
+		return indexes.(map[string][]int)
+	}
+	indexes := make(map[string][]int)
+	for _, field := range reflect.VisibleFields(typ) {
+		// Ambiguous fields aren't visible, and shadowed ones come after the
+		// field shadowing them.
+		if _, ok := indexes[field.Name]; !ok {
+			indexes[field.Name] = field.Index
+		}
+	}
+	actual, _ := fieldIndexes.LoadOrStore(typ, indexes)
+	return actual.(map[string][]int)
+}
+
+type taggedFieldsKey struct {
+	typ     reflect.Type
+	tagName string
+}
+
+// taggedFields caches the names of the fields of a struct type by the key set
+// by a tag, by taggedFieldsKey.
+var taggedFields sync.Map
+
+// taggedFieldName returns the name of the field of the struct type typ whose
+// mergo name tag or tagName tag sets key, see WithTagName.
+func taggedFieldName(typ reflect.Type, key, tagName string) (string, bool) {
+	cacheKey := taggedFieldsKey{typ, tagName}
+	names, ok := taggedFields.Load(cacheKey)
+	if !ok {
+		byKey := make(map[string]string)
+		indexes := fieldIndexesOf(typ)
+		for _, field := range reflect.VisibleFields(typ) {
+			if len(indexes[field.Name]) != len(field.Index) {
+				// Shadowed, so it can't be found by its name.
+				continue
+			}
+			if tag, ok := fieldTagKey(field, tagName); ok {
+				if _, ok := byKey[tag]; !ok {
+					byKey[tag] = field.Name
+				}
+			}
+		}
+		names, _ = taggedFields.LoadOrStore(cacheKey, byKey)
+	}
+	name, ok := names.(map[string]string)[key]
+	return name, ok
+}
+
+// structField is the metadata of a struct field computed once per type.
+type structField struct {
+	reflect.StructField
+	exported bool
+	// nameTagged is set when a mergo name tag sets the key of the field.
+	nameTagged bool
+	// key is the map key derived from the field name, used unless a tag or
+	// casing option sets another one.
+	key reflect.Value
+	// readOnly, transform and sliceStrategy are set by its mergo tag.
+	readOnly         bool
+	transform        string
+	hasTransform     bool
+	sliceStrategy    string
+	hasSliceStrategy bool
+}
+
+// structType is the metadata of a struct type computed once.
+type structType struct {
+	fields []structField
+	// mergeable is set when it has exported fields, see hasMergeableFields.
+	mergeable bool
+	// values is the count of values a struct of the type holds, itself and
+	// its fields, including the ones of the structs it embeds by value.
+	values int
+}
+
+// structTypes caches the metadata of struct types, and fieldNames the fields of
+// struct types by the map keys binding them by default, by reflect.Type.
+var structTypes, fieldNames sync.Map
+
+// structTypeOf returns the metadata of the struct type typ.
+func structTypeOf(typ reflect.Type) *structType {
+	if meta, ok := structTypes.Load(typ); ok {
+		return meta.(*structType)
+	}
+	meta := &structType{fields: make([]structField, typ.NumField()), values: 1}
+	for i := range meta.fields {
+		field := typ.Field(i)
+		_, nameTagged := fieldTagKey(field, "")
+		f := structField{
+			StructField: field,
+			exported:    isExported(field),
+			nameTagged:  nameTagged,
+			key:         reflect.ValueOf(changeInitialCase(field.Name, unicode.ToLower)),
+			readOnly:    isReadOnly(field),
+		}
+		f.transform, f.hasTransform = transformName(field)
+		f.sliceStrategy, f.hasSliceStrategy = tagOption(field, "slice=")
+		meta.fields[i] = f
+		if field.Type.Kind() == reflect.Struct {
+			meta.values += structTypeOf(field.Type).values
+		} else {
+			meta.values++
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			meta.mergeable = meta.mergeable || structTypeOf(field.Type).mergeable
+		} else if isExportedComponent(&field) {
+			meta.mergeable = meta.mergeable || len(field.PkgPath) == 0
+		}
+	}
+	actual, _ := structTypes.LoadOrStore(typ, meta)
+	return actual.(*structType)
+}
+
+// typeTraits are the interfaces deepMerge looks for in every type it merges,
+// computed once per type.
+type typeTraits struct {
+	// orderedMap is set when the type or a pointer to it is an OrderedMap.
+	orderedMap bool
+	// error is set when the type implements error.
+	error bool
+}
+
+// traits caches the typeTraits of types by reflect.Type.
+var traits sync.Map
+
+func traitsOf(typ reflect.Type) typeTraits {
+	if t, ok := traits.Load(typ); ok {
+		return t.(typeTraits)
+	}
+	t := typeTraits{
+		orderedMap: typ.Implements(orderedMapType) || reflect.PtrTo(typ).Implements(orderedMapType),
+		error:      typ.Implements(errorType),
+	}
+	traits.Store(typ, t)
+	return t
+}
+
+// newVisited returns the map tracking the values visited while merging into
+// dst, sized for the values dst holds itself.
+func newVisited(dst reflect.Value) map[visit]bool {
+	if dst.Kind() != reflect.Struct {
+		return make(map[visit]bool)
+	}
+	return make(map[visit]bool, structTypeOf(dst.Type()).values)
+}
+
+// structFieldsOf returns the fields of the struct type typ along with their
+// metadata.
+func structFieldsOf(typ reflect.Type) []structField {
+	return structTypeOf(typ).fields
+}
+
+// mapKey returns the key of field f in a map with keys of type keyType, as set
+// by fieldKey.
+func (f *structField) mapKey(keyType reflect.Type, config *Config) reflect.Value {
+	key := f.key
+	if f.nameTagged || config.tagName != "" || config.acronyms != nil || config.keyAcronyms != nil {
+		key = reflect.ValueOf(fieldKey(f.StructField, config))
+	}
+	if key.Type() != keyType {
+		key = key.Convert(keyType)
+	}
+	return key
+}
+
+// keyFieldName returns the name of the field of the struct type typ bound by
+// key by default, as set by keyName.
+func keyFieldName(typ reflect.Type, key string, config *Config) string {
+	if config.acronyms != nil {
+		return keyName(key, config)
+	}
+	names, ok := fieldNames.Load(typ)
+	if !ok {
+		byKey := make(map[string]string)
+		for name := range fieldIndexesOf(typ) {
+			for _, k := range []string{name, changeInitialCase(name, unicode.ToLower)} {
+				if changeInitialCase(k, unicode.ToUpper) == name {
+					byKey[k] = name
+				}
+			}
+		}
+		names, _ = fieldNames.LoadOrStore(typ, byKey)
+	}
+	if name, ok := names.(map[string]string)[key]; ok {
+		return name
+	}
+	return changeInitialCase(key, unicode.ToUpper)
+}
@@ -0,0 +1,148 @@
+package mergo_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+const wideStructFields = 200
+
+// wideStruct returns a new struct with many int fields, named F0, F1 and so
+// on, along with a map holding a value for every one of them.
+func wideStruct() (reflect.Type, map[string]interface{}) {
+	fields := make([]reflect.StructField, wideStructFields)
+	src := make(map[string]interface{}, wideStructFields)
+	for i := range fields {
+		fields[i] = reflect.StructField{Name: fmt.Sprintf("F%d", i), Type: reflect.TypeOf(0)}
+		src[fmt.Sprintf("f%d", i)] = i
+	}
+	return reflect.StructOf(fields), src
+}
+
+func TestMapWideStruct(t *testing.T) {
+	typ, src := wideStruct()
+	dst := reflect.New(typ)
+	if err := mergo.Map(dst.Interface(), src); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < wideStructFields; i++ {
+		if v := dst.Elem().Field(i).Int(); v != int64(i) {
+			t.Fatalf("expected F%d to be %d, got %d", i, i, v)
+		}
+	}
+}
+
+func BenchmarkMapWideStruct(b *testing.B) {
+	typ, src := wideStruct()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := mergo.Map(reflect.New(typ).Interface(), src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFieldByNameWideStruct measures the uncached lookups that
+// BenchmarkMapWideStruct avoids.
+func BenchmarkFieldByNameWideStruct(b *testing.B) {
+	typ, src := wideStruct()
+	dst := reflect.New(typ).Elem()
+	names := make([]string, 0, len(src))
+	for i := 0; i < wideStructFields; i++ {
+		names = append(names, fmt.Sprintf("F%d", i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, name := range names {
+			dst.FieldByName(name)
+		}
+	}
+}
+
+type benchmarkLeaf struct {
+	Name    string
+	Port    int
+	Enabled bool
+	Ratio   float64
+}
+
+// benchmarkConfig has over 20 fields, some of them nested structs.
+type benchmarkConfig struct {
+	F0, F1, F2, F3, F4, F5, F6, F7, F8, F9 string
+	I0, I1, I2, I3, I4, I5, I6, I7, I8, I9 int
+	Primary, Secondary                     benchmarkLeaf
+	Backup                                 *benchmarkLeaf
+	Labels                                 map[string]string
+	Tags                                   []string
+}
+
+func newBenchmarkConfig() benchmarkConfig {
+	return benchmarkConfig{
+		F0: "a", F3: "d", F7: "h",
+		I1: 1, I5: 5, I9: 9,
+		Primary: benchmarkLeaf{Name: "primary", Port: 80},
+		Backup:  &benchmarkLeaf{Name: "backup", Enabled: true},
+		Labels:  map[string]string{"app": "api"},
+		Tags:    []string{"edge"},
+	}
+}
+
+func BenchmarkMergeNestedStruct(b *testing.B) {
+	src := newBenchmarkConfig()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dst := benchmarkConfig{F1: "b", I2: 2, Secondary: benchmarkLeaf{Ratio: 0.5}}
+		if err := mergo.Merge(&dst, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMapStructToMap(b *testing.B) {
+	src := newBenchmarkConfig()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dst := make(map[string]interface{})
+		if err := mergo.Map(&dst, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMapMapToStruct(b *testing.B) {
+	src := make(map[string]interface{})
+	if err := mergo.Map(&src, newBenchmarkConfig()); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var dst benchmarkConfig
+		if err := mergo.Map(&dst, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMapConcurrentSameType(t *testing.T) {
+	src := newBenchmarkConfig()
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			dst := make(map[string]interface{})
+			if err := mergo.Map(&dst, src); err != nil {
+				errs <- err
+				return
+			}
+			var back benchmarkConfig
+			errs <- mergo.Map(&back, dst)
+		}()
+	}
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package mergo_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type indexedRoutes struct {
+	Routes []string
+	Index  map[string]int
+}
+
+func rebuildIndex(dst interface{}) error {
+	routes := dst.(*indexedRoutes)
+	routes.Index = make(map[string]int, len(routes.Routes))
+	for i, route := range routes.Routes {
+		routes.Index[route] = i
+	}
+	return nil
+}
+
+func TestFinalizer(t *testing.T) {
+	dst := indexedRoutes{Routes: []string{"/a"}}
+	calls := 0
+	finalize := func(dst interface{}) error {
+		calls++
+		return rebuildIndex(dst)
+	}
+
+	err := mergo.Merge(&dst, indexedRoutes{Routes: []string{"/b", "/c"}}, mergo.WithAppendSlice, mergo.WithFinalizer(finalize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the finalizer to be called once, got %d calls", calls)
+	}
+	if len(dst.Index) != 3 || dst.Index["/c"] != 2 {
+		t.Errorf("expected the index to be rebuilt, got %v", dst.Index)
+	}
+}
+
+func TestFinalizerError(t *testing.T) {
+	errInvalid := errors.New("invalid routes")
+	failing := mergo.WithFinalizer(func(interface{}) error { return errInvalid })
+
+	dst := indexedRoutes{}
+	if err := mergo.Merge(&dst, indexedRoutes{Routes: []string{"/a"}}, failing); !errors.Is(err, errInvalid) {
+		t.Errorf("expected %v, got %v", errInvalid, err)
+	}
+
+	var v atomic.Value
+	v.Store(&indexedRoutes{Routes: []string{"/a"}})
+	if err := mergo.MergeAtomic(&v, indexedRoutes{Routes: []string{"/b"}}, mergo.WithAppendSlice, failing); !errors.Is(err, errInvalid) {
+		t.Errorf("expected %v, got %v", errInvalid, err)
+	}
+	if routes := v.Load().(*indexedRoutes).Routes; len(routes) != 1 {
+		t.Errorf("expected the stored value to be left alone, got %v", routes)
+	}
+}
+
+func TestFinalizerNotCalledOnError(t *testing.T) {
+	called := false
+	dst := indexedRoutes{}
+	err := mergo.Merge(&dst, simpleTest{}, mergo.WithFinalizer(func(interface{}) error {
+		called = true
+		return nil
+	}))
+	if err == nil || called {
+		t.Errorf("expected a failed merge not to be finalized, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// MapForm binds form, like the url.Values of an HTTP request, into dst, which
+// must be a pointer to struct. Keys are matched with fields the same way Map
+// does. The first value of a key binds a scalar field, parsed from its text,
+// while all of them bind a slice field. Keys without values, keys without a
+// matching field and empty values are skipped, and bound values are merged
+// following the given options.
+func MapForm(dst interface{}, form map[string][]string, opts ...func(*Config)) error {
+	if dst == nil || form == nil {
+		return ErrNilArguments
+	}
+	vDst := reflect.ValueOf(dst)
+	if vDst.Kind() != reflect.Ptr {
+		return ErrNonPointerAgument
+	}
+	if vDst = vDst.Elem(); vDst.Kind() != reflect.Struct {
+		return ErrExpectedStructAsDestination
+	}
+	config := &Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	visited := make(map[visit]bool)
+	pushField(config, "")
+	defer popPath(config)
+	for _, k := range mapKeys(reflect.ValueOf(form), config) {
+		key := k.String()
+		config.path[len(config.path)-1] = pathElement{field: key}
+		values := form[key]
+		if len(values) == 0 {
+			continue
+		}
+		fieldName, tagged := taggedFieldName(vDst.Type(), key, config.tagName)
+		if !tagged {
+			fieldName = keyName(key, config)
+		}
+		field := fieldByName(vDst, fieldName)
+		if !field.IsValid() || !field.CanSet() {
+			// We discard it because the field doesn't exist.
+			continue
+		}
+		if isReadOnlyByName(vDst.Type(), fieldName) {
+			traceDecision(config, TraceProtect, field, field)
+			continue
+		}
+		value, ok, err := parseFormValues(field.Type(), values)
+		if err != nil {
+			return fmt.Errorf("cannot bind %s field: %w", key, err)
+		}
+		if !ok {
+			continue
+		}
+		if field.Kind() == reflect.Struct && isEmptyStruct(field) {
+			// Parsed structs, like time.Time, are opaque values.
+			field.Set(value)
+		} else if err = deepMerge(field, value, visited, 1, config); err != nil {
+			return err
+		}
+	}
+	return collectErrors(config, nil)
+}
+
+// parseFormValues parses values into a value of typ, a slice getting all of
+// them and any other type the first one. It reports whether there was a
+// non-empty value to parse.
+func parseFormValues(typ reflect.Type, values []string) (reflect.Value, bool, error) {
+	if typ.Kind() != reflect.Slice || typ.Elem().Kind() == reflect.Uint8 || typ.Implements(textUnmarshalerType) {
+		if values[0] == "" {
+			return reflect.Value{}, false, nil
+		}
+		value, err := parseFormValue(typ, values[0])
+		return value, err == nil, err
+	}
+	slice := reflect.MakeSlice(typ, 0, len(values))
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		element, err := parseFormValue(typ.Elem(), v)
+		if err != nil {
+			return reflect.Value{}, false, err
+		}
+		slice = reflect.Append(slice, element)
+	}
+	return slice, slice.Len() > 0, nil
+}
+
+// parseFormValue parses s into a value of typ, a pointer, a text unmarshaler
+// or a scalar.
+func parseFormValue(typ reflect.Type, s string) (reflect.Value, error) {
+	if typ.Kind() == reflect.Ptr {
+		value, err := parseFormValue(typ.Elem(), s)
+		if err != nil {
+			return value, err
+		}
+		ptr := reflect.New(typ.Elem())
+		ptr.Elem().Set(value)
+		return ptr, nil
+	}
+	value := reflect.New(typ).Elem()
+	if u, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return value, u.UnmarshalText([]byte(s))
+	}
+	switch k := typ.Kind(); {
+	case k == reflect.String:
+		value.SetString(s)
+	case k == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return value, err
+		}
+		value.SetBool(b)
+	case isIntKind(k):
+		i, err := strconv.ParseInt(s, 10, typ.Bits())
+		if err != nil {
+			return value, err
+		}
+		value.SetInt(i)
+	case isUintKind(k):
+		u, err := strconv.ParseUint(s, 10, typ.Bits())
+		if err != nil {
+			return value, err
+		}
+		value.SetUint(u)
+	case isFloatKind(k):
+		f, err := strconv.ParseFloat(s, typ.Bits())
+		if err != nil {
+			return value, err
+		}
+		value.SetFloat(f)
+	case k == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+		value.SetBytes([]byte(s))
+	default:
+		return value, fmt.Errorf("%w: cannot parse into %s", ErrNotSupported, typ)
+	}
+	return value, nil
+}
@@ -0,0 +1,92 @@
+package mergo_test
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/imdario/mergo"
+)
+
+type signupForm struct {
+	Name     string
+	Age      int
+	Ratio    float64
+	Accept   bool
+	Tags     []string
+	Scores   []int
+	Nickname *string
+	Birthday time.Time
+	Referrer string `form:"ref"`
+}
+
+func TestMapForm(t *testing.T) {
+	form := url.Values{
+		"name":     {"gopher"},
+		"age":      {"12"},
+		"ratio":    {"0.5"},
+		"accept":   {"true"},
+		"tags":     {"go", "", "mergo"},
+		"scores":   {"1", "2"},
+		"nickname": {"gophy"},
+		"birthday": {"2009-11-10T23:00:00Z"},
+		"ref":      {"newsletter"},
+		"unknown":  {"skipped"},
+	}
+	dst := signupForm{}
+
+	if err := mergo.MapForm(&dst, form, mergo.WithTagName("form")); err != nil {
+		t.Fatal(err)
+	}
+	nickname := "gophy"
+	expected := signupForm{
+		Name:     "gopher",
+		Age:      12,
+		Ratio:    0.5,
+		Accept:   true,
+		Tags:     []string{"go", "mergo"},
+		Scores:   []int{1, 2},
+		Nickname: &nickname,
+		Birthday: time.Date(2009, 11, 10, 23, 0, 0, 0, time.UTC),
+		Referrer: "newsletter",
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+}
+
+func TestMapFormEmptyValues(t *testing.T) {
+	dst := signupForm{Name: "gopher", Age: 12}
+	form := url.Values{"name": {""}, "age": {}, "tags": {""}}
+
+	if err := mergo.MapForm(&dst, form, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (signupForm{Name: "gopher", Age: 12}); !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected empty values to be skipped, got %+v", dst)
+	}
+
+	if err := mergo.MapForm(&dst, url.Values{"name": {"other"}}); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "gopher" {
+		t.Errorf("expected non-empty fields to be kept without override, got %q", dst.Name)
+	}
+	if err := mergo.MapForm(&dst, url.Values{"name": {"other"}}, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "other" {
+		t.Errorf("expected override to bind the value, got %q", dst.Name)
+	}
+}
+
+func TestMapFormInvalidValue(t *testing.T) {
+	dst := signupForm{}
+	err := mergo.MapForm(&dst, url.Values{"age": {"twelve"}})
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected a syntax error, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func callbacks(calls *[]string, names ...string) map[string]func() {
+	m := make(map[string]func(), len(names))
+	for _, name := range names {
+		name := name
+		m[name] = func() { *calls = append(*calls, name) }
+	}
+	return m
+}
+
+func callAll(m map[string]func(), keys ...string) {
+	for _, key := range keys {
+		m[key]()
+	}
+}
+
+func TestMergeFuncMap(t *testing.T) {
+	var dstCalls, srcCalls []string
+	dst := callbacks(&dstCalls, "start")
+	dst["unset"] = nil
+	src := callbacks(&srcCalls, "start", "stop", "unset")
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	callAll(dst, "start", "stop", "unset")
+	if len(dstCalls) != 1 || dstCalls[0] != "start" {
+		t.Errorf("expected the dst callback to be kept, got %v", dstCalls)
+	}
+	if len(srcCalls) != 2 || srcCalls[0] != "stop" || srcCalls[1] != "unset" {
+		t.Errorf("expected the missing and nil callbacks to be added, got %v", srcCalls)
+	}
+}
+
+func TestMergeFuncMapWithOverride(t *testing.T) {
+	var dstCalls, srcCalls []string
+	dst := callbacks(&dstCalls, "start", "stop")
+	src := callbacks(&srcCalls, "start")
+	src["stop"] = nil
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	callAll(dst, "start", "stop")
+	if len(srcCalls) != 1 || srcCalls[0] != "start" {
+		t.Errorf("expected the src callback to replace the dst one, got %v", srcCalls)
+	}
+	if len(dstCalls) != 1 || dstCalls[0] != "stop" {
+		t.Errorf("expected a nil src callback not to replace the dst one, got %v", dstCalls)
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverwriteWithEmptyValue); err != nil {
+		t.Fatal(err)
+	}
+	if dst["stop"] != nil {
+		t.Errorf("expected a nil src callback to replace the dst one with empty values")
+	}
+}
+
+func TestMergeFuncMapField(t *testing.T) {
+	type hooks struct {
+		On map[string]func()
+	}
+	var calls []string
+	dst := hooks{}
+	src := hooks{On: callbacks(&calls, "save")}
+
+	if err := mergo.Merge(&dst, src, mergo.WithMapDeepCopy); err != nil {
+		t.Fatal(err)
+	}
+	callAll(dst.On, "save")
+	if len(calls) != 1 {
+		t.Errorf("expected the callback to be copied, got %v", calls)
+	}
+}
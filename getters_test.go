@@ -0,0 +1,99 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type person struct {
+	First string
+	Last  string
+}
+
+func (p person) FullName() string {
+	return p.First + " " + p.Last
+}
+
+func (p *person) Initials() string {
+	return p.First[:1] + p.Last[:1]
+}
+
+func (p person) First2(n int) string {
+	return p.First[:n]
+}
+
+func (p person) Split() (string, string) {
+	return p.First, p.Last
+}
+
+type closer struct {
+	Name   string
+	closed *bool
+}
+
+func (c closer) Close() error {
+	*c.closed = true
+	return nil
+}
+
+func (c closer) Label() string {
+	return "label:" + c.Name
+}
+
+func TestMapWithIncludeGetters(t *testing.T) {
+	src := &person{First: "Ada", Last: "Lovelace"}
+	dst := map[string]interface{}{}
+
+	if err := mergo.Map(&dst, src, mergo.WithIncludeGetters); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"first":    "Ada",
+		"last":     "Lovelace",
+		"fullName": "Ada Lovelace",
+		"initials": "AL",
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestMapWithoutGetters(t *testing.T) {
+	dst := map[string]interface{}{}
+	if err := mergo.Map(&dst, person{First: "Ada", Last: "Lovelace"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := dst["fullName"]; ok {
+		t.Errorf("getters must not be mapped by default, got %v", dst)
+	}
+}
+
+func TestMapWithIncludeGettersKeepsDst(t *testing.T) {
+	dst := map[string]interface{}{"fullName": "custom"}
+	if err := mergo.Map(&dst, person{First: "Ada", Last: "Lovelace"}, mergo.WithIncludeGetters); err != nil {
+		t.Fatal(err)
+	}
+	if dst["fullName"] != "custom" {
+		t.Errorf("expected fullName to be kept, got %v", dst["fullName"])
+	}
+	if _, ok := dst["initials"]; ok {
+		t.Errorf("pointer receiver getters can't be called on an unaddressable src, got %v", dst)
+	}
+}
+
+func TestMapWithGetters(t *testing.T) {
+	closed := false
+	dst := map[string]interface{}{}
+	if err := mergo.Map(&dst, closer{Name: "db", closed: &closed}, mergo.WithGetters("Label")); err != nil {
+		t.Fatal(err)
+	}
+	if closed {
+		t.Error("Close was called, only the methods given must be")
+	}
+	expected := map[string]interface{}{"name": "db", "label": "label:db"}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
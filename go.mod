@@ -1,5 +1,5 @@
 module github.com/imdario/mergo
 
-go 1.13
+go 1.18
 
 require gopkg.in/yaml.v2 v2.3.0
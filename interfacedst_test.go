@@ -0,0 +1,76 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type anyConfig struct {
+	Name string
+	Port int
+}
+
+func TestMergeIntoNilInterface(t *testing.T) {
+	var dst interface{}
+	src := anyConfig{Name: "src", Port: 80}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst != src {
+		t.Errorf("expected %v, got %v", src, dst)
+	}
+
+	var ptrDst interface{}
+	ptrSrc := &anyConfig{Name: "src"}
+	if err := mergo.Merge(&ptrDst, ptrSrc); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := ptrDst.(*anyConfig)
+	if !ok || got == ptrSrc || *got != *ptrSrc {
+		t.Errorf("expected a copy of %v, got %#v", *ptrSrc, ptrDst)
+	}
+}
+
+func TestMergeIntoInterfaceHoldingValue(t *testing.T) {
+	var dst interface{} = anyConfig{Name: "dst"}
+
+	if err := mergo.Merge(&dst, anyConfig{Name: "src", Port: 80}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (anyConfig{Name: "dst", Port: 80}); dst != expected {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestMergeIntoInterfaceHoldingPointer(t *testing.T) {
+	held := &anyConfig{Port: 1}
+	var dst interface{} = held
+
+	if err := mergo.Merge(&dst, &anyConfig{Name: "src", Port: 2}, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst != held || *held != (anyConfig{Name: "src", Port: 2}) {
+		t.Errorf("expected the pointed value to be merged, got %v", dst)
+	}
+}
+
+func TestMergeIntoInterfaceHoldingMap(t *testing.T) {
+	var dst interface{} = map[string]interface{}{"a": 1}
+
+	if err := mergo.Merge(&dst, map[string]interface{}{"b": 2}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]interface{}{"a": 1, "b": 2}; !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestMergeIntoInterfaceDifferentType(t *testing.T) {
+	var dst interface{} = "not a struct"
+	if err := mergo.Merge(&dst, anyConfig{}); err != mergo.ErrDifferentArgumentsTypes {
+		t.Errorf("expected %v, got %v", mergo.ErrDifferentArgumentsTypes, err)
+	}
+}
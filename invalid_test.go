@@ -0,0 +1,120 @@
+package mergo_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type pathologicalInner struct {
+	A int
+}
+
+type pathological struct {
+	I  interface{}
+	PI *interface{}
+	SI []pathologicalInner
+	MS map[string]pathologicalInner
+	MP map[string]*pathologicalInner
+	L  []pathologicalKeyed
+}
+
+type pathologicalKeyed struct {
+	ID interface{}
+}
+
+func TestMergeMixedInterfaceKinds(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dst, src interface{}
+		expected interface{}
+	}{
+		{"map replaced by nil pointer", map[string]interface{}{"a": map[string]interface{}{"b": 1}}, (*pathologicalInner)(nil), nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := map[string]interface{}{"k": tc.dst}
+			if err := mergo.Merge(&dst, map[string]interface{}{"k": tc.src}, mergo.WithOverride); err != nil {
+				t.Fatal(err)
+			}
+			if tc.expected == nil {
+				if !reflect.ValueOf(dst["k"]).IsNil() {
+					t.Errorf("expected a nil value, got %v", dst["k"])
+				}
+			} else if !reflect.DeepEqual(dst["k"], tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, dst["k"])
+			}
+		})
+	}
+}
+
+func TestMergeMixedInterfaceKindsClash(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dst, src interface{}
+	}{
+		{"map into struct", pathologicalInner{A: 1}, map[string]interface{}{"A": 2}},
+		{"slice into int", 1, []interface{}{2}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := map[string]interface{}{"k": tc.dst}
+			err := mergo.Merge(&dst, map[string]interface{}{"k": tc.src}, mergo.WithOverride)
+			var mismatch *mergo.TypeMismatchError
+			if !errors.As(err, &mismatch) || mismatch.Path != "[k]" {
+				t.Fatalf("expected a mismatch at [k], got %v", err)
+			}
+			if !reflect.DeepEqual(dst["k"], tc.dst) {
+				t.Errorf("expected %v to be kept, got %v", tc.dst, dst["k"])
+			}
+		})
+	}
+}
+
+func TestMapMismatchedContainers(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  map[string]interface{}
+	}{
+		{"map of interfaces into map of structs", map[string]interface{}{"mS": map[string]interface{}{"k": nil}}},
+		{"slice of interfaces into slice of structs", map[string]interface{}{"sI": []interface{}{nil}}},
+		{"map into pointer to interface", map[string]interface{}{"pI": map[string]interface{}{"A": 1}}},
+		{"slice into map of pointers", map[string]interface{}{"mP": map[string]interface{}{"k": []interface{}{1}}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := pathological{}
+			err := mergo.Map(&dst, tc.src, mergo.WithOverride)
+			if !errors.Is(err, mergo.ErrDifferentArgumentsTypes) && !errors.Is(err, mergo.ErrNotSupported) {
+				t.Errorf("expected a type error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSliceMergeByUnhashableKeys(t *testing.T) {
+	dst := pathological{L: []pathologicalKeyed{{ID: []int{1}}}}
+	src := pathological{L: []pathologicalKeyed{{ID: map[string]int{"a": 1}}, {ID: 2}}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceMergeByKeys("ID")); err != nil {
+		t.Fatal(err)
+	}
+	if len(dst.L) != 3 {
+		t.Errorf("expected elements with unhashable keys to be appended, got %v", dst.L)
+	}
+}
+
+func TestMergeTypedNilSrc(t *testing.T) {
+	dst := pathological{I: 1}
+	if err := mergo.Merge(&dst, (*pathological)(nil)); err != mergo.ErrNilArguments {
+		t.Errorf("expected %v, got %v", mergo.ErrNilArguments, err)
+	}
+	if err := mergo.Map(&dst, (*map[string]interface{})(nil)); err != mergo.ErrNilArguments {
+		t.Errorf("expected %v from Map, got %v", mergo.ErrNilArguments, err)
+	}
+	if dst.I != 1 {
+		t.Errorf("expected dst to be untouched, got %+v", dst)
+	}
+}
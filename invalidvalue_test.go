@@ -0,0 +1,40 @@
+package mergo_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type invalidValueConfig struct {
+	Name string
+	Port int
+}
+
+func TestMapSkipsInvalidValues(t *testing.T) {
+	var nilPort *int
+	dst := invalidValueConfig{Name: "dst", Port: 80}
+	src := map[string]interface{}{"name": nil, "port": nilPort}
+
+	if err := mergo.Map(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (invalidValueConfig{Name: "dst", Port: 80}); dst != expected {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestMapErrorOnInvalidValue(t *testing.T) {
+	var nilPort *int
+	for key, value := range map[string]interface{}{"name": nil, "port": nilPort} {
+		dst := invalidValueConfig{}
+		err := mergo.Map(&dst, map[string]interface{}{key: value}, mergo.WithErrorOnInvalidValue)
+		if !errors.Is(err, mergo.ErrInvalidValue) {
+			t.Errorf("expected %v, got %v", mergo.ErrInvalidValue, err)
+		} else if !strings.Contains(err.Error(), key) {
+			t.Errorf("expected the error to name the %q key, got %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func nestedMap(depth int, leafKey string) map[string]interface{} {
+	root := map[string]interface{}{}
+	current := root
+	for i := 0; i < depth; i++ {
+		next := map[string]interface{}{}
+		current["child"] = next
+		current = next
+	}
+	current[leafKey] = leafKey
+	return root
+}
+
+func TestIterativeMapMergeDeepNesting(t *testing.T) {
+	const depth = 10000
+	dst := nestedMap(depth, "dst")
+	src := nestedMap(depth, "src")
+
+	if err := mergo.Merge(&dst, src, mergo.WithIterativeMapMerge); err != nil {
+		t.Fatal(err)
+	}
+	current := dst
+	for i := 0; i < depth; i++ {
+		next, ok := current["child"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("expected a nested map at level %d, got %T", i, current["child"])
+		}
+		current = next
+	}
+	if current["dst"] != "dst" || current["src"] != "src" {
+		t.Errorf("expected both leaves to be merged, got %v", current)
+	}
+}
+
+func TestIterativeMapMergeMatchesRecursive(t *testing.T) {
+	newDst := func() map[string]interface{} {
+		return map[string]interface{}{
+			"name": "dst",
+			"properties": map[string]interface{}{
+				"field1": map[string]interface{}{"type": "text"},
+				"field2": "ohai",
+			},
+		}
+	}
+	src := map[string]interface{}{
+		"name": "src",
+		"properties": map[string]interface{}{
+			"field1": map[string]interface{}{"index": true},
+			"field3": []string{"a"},
+		},
+		"new": 1,
+	}
+	for _, opts := range [][]func(*mergo.Config){nil, {mergo.WithOverride}} {
+		recursive, iterative := newDst(), newDst()
+		if err := mergo.Merge(&recursive, src, opts...); err != nil {
+			t.Fatal(err)
+		}
+		if err := mergo.Merge(&iterative, src, append(opts, mergo.WithIterativeMapMerge)...); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(recursive, iterative) {
+			t.Errorf("expected %v, got %v", recursive, iterative)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package mergo_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type jsonNumberConfig struct {
+	Port    int
+	Retries uint8
+	Ratio   float64
+	Limit   int64
+}
+
+func TestJSONNumber(t *testing.T) {
+	decoder := json.NewDecoder(strings.NewReader(`{"port": 8080, "retries": 3, "ratio": 0.5, "limit": 1e3}`))
+	decoder.UseNumber()
+	var src map[string]interface{}
+	if err := decoder.Decode(&src); err != nil {
+		t.Fatal(err)
+	}
+	var dst jsonNumberConfig
+
+	if err := mergo.Map(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (jsonNumberConfig{Port: 8080, Retries: 3, Ratio: 0.5, Limit: 1000}); dst != expected {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+}
+
+func TestJSONNumberErrors(t *testing.T) {
+	for _, src := range []map[string]interface{}{
+		{"retries": json.Number("256")},
+		{"retries": json.Number("-1")},
+		{"port": json.Number("1.5")},
+		{"port": json.Number("abc")},
+	} {
+		var dst jsonNumberConfig
+		if err := mergo.Map(&dst, src); err == nil {
+			t.Errorf("expected an error binding %v, got %+v", src, dst)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// MergeJSONPatch binds the keys present in jsonData into dst, which must be a
+// pointer to struct. Keys absent from the document never touch dst, while
+// present keys are applied even if their value is empty (0, "", false, null).
+// This allows JSON overlays to tell apart an absent key from a zero one.
+// Nested objects are patched recursively with their own presence mask.
+func MergeJSONPatch(dst interface{}, jsonData []byte, opts ...func(*Config)) error {
+	if dst == nil {
+		return ErrNilArguments
+	}
+	vDst := reflect.ValueOf(dst)
+	if vDst.Kind() != reflect.Ptr {
+		return ErrNonPointerAgument
+	}
+	if vDst = vDst.Elem(); vDst.Kind() != reflect.Struct {
+		return ErrExpectedStructAsDestination
+	}
+	config := &Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	// Presence is what matters here, so any present value must win.
+	config.Overwrite = true
+	config.overwriteWithEmptyValue = true
+	return collectErrors(config, patchStruct(vDst, jsonData, 0, config))
+}
+
+// patchStruct applies the keys of the JSON object data to dst in document
+// order, so like with encoding/json the last of the keys bound to the same
+// field wins.
+func patchStruct(dst reflect.Value, data []byte, depth int, config *Config) error {
+	if !json.Valid(data) || !isJSONObject(data) {
+		// Let encoding/json report the error, if any, as null is no patch.
+		var present map[string]json.RawMessage
+		return json.Unmarshal(data, &present)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		var raw json.RawMessage
+		if err = decoder.Decode(&raw); err != nil {
+			return err
+		}
+		field, ok := jsonField(dst, key)
+		if !ok || !field.CanSet() {
+			// We discard it because the field doesn't exist.
+			continue
+		}
+		pushField(config, key)
+		err = patchField(field, raw, depth+1, config)
+		popPath(config)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func patchField(dst reflect.Value, raw json.RawMessage, depth int, config *Config) error {
+	if isJSONObject(raw) {
+		switch {
+		case dst.Kind() == reflect.Struct:
+			return patchStruct(dst, raw, depth, config)
+		case dst.Kind() == reflect.Ptr && dst.Type().Elem().Kind() == reflect.Struct:
+			if dst.IsNil() {
+				dst.Set(reflect.New(dst.Type().Elem()))
+			}
+			return patchStruct(dst.Elem(), raw, depth, config)
+		}
+	}
+	src := reflect.New(dst.Type())
+	if err := json.Unmarshal(raw, src.Interface()); err != nil {
+		return err
+	}
+	// Every key is merged on its own, as later ones may set the same field.
+	return deepMerge(dst, src.Elem(), newVisited(dst), depth, config)
+}
+
+func isJSONObject(raw json.RawMessage) bool {
+	raw = bytes.TrimLeft(raw, " \t\r\n")
+	return len(raw) > 0 && raw[0] == '{'
+}
+
+// jsonField finds the field of v bound to key following encoding/json rules:
+// among the fields sharing a name the shallowest one dominates, and an exact
+// match of the name wins over a case-insensitive one, which is otherwise the
+// shallowest matching field.
+func jsonField(v reflect.Value, key string) (reflect.Value, bool) {
+	fields := jsonFieldsOf(v.Type())
+	folded := -1
+	for i, field := range fields {
+		if field.name == key {
+			return v.FieldByIndex(field.index), true
+		}
+		if strings.EqualFold(field.name, key) && (folded == -1 || len(field.index) < len(fields[folded].index)) {
+			folded = i
+		}
+	}
+	if folded == -1 {
+		return reflect.Value{}, false
+	}
+	return v.FieldByIndex(fields[folded].index), true
+}
+
+// jsonFieldInfo is a field of a struct bound by encoding/json, with its index
+// sequence from the struct, through the embedded structs promoting it.
+type jsonFieldInfo struct {
+	name   string
+	index  []int
+	tagged bool
+}
+
+var jsonFields sync.Map
+
+// jsonFieldsOf returns the dominant fields of the struct type typ, in index
+// order: the shallowest for every name, or the only tagged one among them.
+// Names left ambiguous aren't bound at all.
+func jsonFieldsOf(typ reflect.Type) []jsonFieldInfo {
+	if fields, ok := jsonFields.Load(typ); ok {
+		return fields.([]jsonFieldInfo)
+	}
+	all := collectJSONFields(typ, nil, nil)
+	byName := make(map[string][]int, len(all))
+	for i, field := range all {
+		byName[field.name] = append(byName[field.name], i)
+	}
+	var dominant []jsonFieldInfo
+	for i, field := range all {
+		if dominantJSONField(all, byName[field.name]) == i {
+			dominant = append(dominant, field)
+		}
+	}
+	fields, _ := jsonFields.LoadOrStore(typ, dominant)
+	return fields.([]jsonFieldInfo)
+}
+
+// collectJSONFields appends to fields the ones of typ, found at index, and
+// the ones promoted from its untagged embedded structs, depth first.
+func collectJSONFields(typ reflect.Type, index []int, fields []jsonFieldInfo) []jsonFieldInfo {
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || isReadOnly(field) {
+			continue
+		}
+		name := tag
+		if idx := strings.Index(tag, ","); idx != -1 {
+			name = tag[:idx]
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			fields = collectJSONFields(field.Type, fieldIndex, fields)
+			continue
+		}
+		if !isExportedComponent(&field) {
+			continue
+		}
+		tagged := name != ""
+		if !tagged {
+			name = field.Name
+		}
+		fields = append(fields, jsonFieldInfo{name, fieldIndex, tagged})
+	}
+	return fields
+}
+
+// dominantJSONField returns which of the candidates, fields sharing a name,
+// dominates the others, or -1 if none does.
+func dominantJSONField(fields []jsonFieldInfo, candidates []int) int {
+	depth := len(fields[candidates[0]].index)
+	for _, c := range candidates[1:] {
+		if d := len(fields[c].index); d < depth {
+			depth = d
+		}
+	}
+	shallowest, tagged := -1, -1
+	var count, taggedCount int
+	for _, c := range candidates {
+		if len(fields[c].index) != depth {
+			continue
+		}
+		shallowest, count = c, count+1
+		if fields[c].tagged {
+			tagged, taggedCount = c, taggedCount+1
+		}
+	}
+	switch {
+	case count == 1:
+		return shallowest
+	case taggedCount == 1:
+		return tagged
+	}
+	return -1
+}
@@ -0,0 +1,144 @@
+package mergo_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type patchTLS struct {
+	Cert string `json:"cert"`
+	Key  string `json:"key"`
+}
+
+type patchConfig struct {
+	Name  string            `json:"name"`
+	Port  int               `json:"port"`
+	Debug bool              `json:"debug"`
+	Tags  []string          `json:"tags,omitempty"`
+	TLS   *patchTLS         `json:"tls"`
+	Extra map[string]string `json:"extra"`
+	Skip  string            `json:"-"`
+}
+
+func newPatchConfig() patchConfig {
+	return patchConfig{
+		Name:  "server",
+		Port:  8080,
+		Debug: true,
+		Tags:  []string{"a", "b"},
+		TLS:   &patchTLS{Cert: "old.crt", Key: "old.key"},
+		Extra: map[string]string{"a": "1"},
+		Skip:  "kept",
+	}
+}
+
+func TestMergeJSONPatchAbsentVsZero(t *testing.T) {
+	dst := newPatchConfig()
+	data := []byte(`{"port": 0, "debug": false, "tags": null, "tls": {"cert": "new.crt"}, "Skip": "ignored"}`)
+
+	if err := mergo.MergeJSONPatch(&dst, data); err != nil {
+		t.Fatal(err)
+	}
+	expected := patchConfig{
+		Name:  "server",
+		Port:  0,
+		Debug: false,
+		TLS:   &patchTLS{Cert: "new.crt", Key: "old.key"},
+		Extra: map[string]string{"a": "1"},
+		Skip:  "kept",
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %#v, got %#v", expected, dst)
+	}
+}
+
+func TestMergeJSONPatchEmptyDocument(t *testing.T) {
+	dst := newPatchConfig()
+	if err := mergo.MergeJSONPatch(&dst, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst, newPatchConfig()) {
+		t.Errorf("dst was modified by an empty patch: %#v", dst)
+	}
+}
+
+func TestMergeJSONPatchNestedAllocationAndMaps(t *testing.T) {
+	dst := patchConfig{Extra: map[string]string{"a": "1"}}
+	data := []byte(`{"TLS": {"key": "new.key"}, "extra": {"b": "2"}}`)
+
+	if err := mergo.MergeJSONPatch(&dst, data); err != nil {
+		t.Fatal(err)
+	}
+	if dst.TLS == nil || dst.TLS.Key != "new.key" || dst.TLS.Cert != "" {
+		t.Errorf("expected TLS to be allocated with only key set, got %#v", dst.TLS)
+	}
+	if !reflect.DeepEqual(dst.Extra, map[string]string{"a": "1", "b": "2"}) {
+		t.Errorf("expected extra to be merged, got %v", dst.Extra)
+	}
+}
+
+func TestMergeJSONPatchErrors(t *testing.T) {
+	dst := newPatchConfig()
+	if err := mergo.MergeJSONPatch(dst, []byte(`{}`)); err != mergo.ErrNonPointerAgument {
+		t.Errorf("expected %v, got %v", mergo.ErrNonPointerAgument, err)
+	}
+	if err := mergo.MergeJSONPatch(&dst, []byte(`{"port": "eighty"}`)); err == nil {
+		t.Error("expected an error binding a string into an int field")
+	}
+}
+
+func TestMergeJSONPatchDocumentOrder(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		dst := patchConfig{}
+		if err := mergo.MergeJSONPatch(&dst, []byte(`{"name": "a", "NAME": "b", "Name": "c"}`)); err != nil {
+			t.Fatal(err)
+		}
+		if dst.Name != "c" {
+			t.Fatalf("expected the last key to win, got %q", dst.Name)
+		}
+	}
+
+	dst := patchConfig{}
+	err := mergo.MergeJSONPatch(&dst, []byte(`{"port": "eighty", "debug": "yes"}`))
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) || typeErr.Value != "string" || typeErr.Type != reflect.TypeOf(0) {
+		t.Errorf("expected the error of the first failing key, got %v", err)
+	}
+}
+
+type patchEmbedded struct {
+	Name string
+	Port int
+}
+
+type patchDominant struct {
+	patchEmbedded
+	Title string `json:"name"`
+	PORT  int
+}
+
+func TestMergeJSONPatchFieldDominance(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expected patchDominant
+	}{
+		{`{"name": "x"}`, patchDominant{Title: "x"}},
+		{`{"Name": "x"}`, patchDominant{patchEmbedded: patchEmbedded{Name: "x"}}},
+		{`{"NAME": "x"}`, patchDominant{Title: "x"}},
+		{`{"Port": 1}`, patchDominant{patchEmbedded: patchEmbedded{Port: 1}}},
+		{`{"port": 1}`, patchDominant{PORT: 1}},
+	}
+	for _, tc := range testCases {
+		dst := patchDominant{}
+		if err := mergo.MergeJSONPatch(&dst, []byte(tc.data)); err != nil {
+			t.Fatal(err)
+		}
+		if dst != tc.expected {
+			t.Errorf("%s: expected %+v, got %+v", tc.data, tc.expected, dst)
+		}
+	}
+}
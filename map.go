@@ -9,8 +9,11 @@
 package mergo
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 )
@@ -28,41 +31,215 @@ func isExported(field reflect.StructField) bool {
 	return r >= 'A' && r <= 'Z'
 }
 
+// fieldKey returns the key used for field when a struct is mapped into a map:
+// the one set by its `mergo:"name=key"` tag, or by its WithTagName tag, or else
+// one derived from its name.
+func fieldKey(field reflect.StructField, config *Config) string {
+	if key, ok := fieldTagKey(field, config.tagName); ok {
+		return key
+	}
+	return nameKey(field.Name, config)
+}
+
+// fieldTagKey returns the key set for field by its mergo name tag, which takes
+// precedence, or else by its tagName tag, if any.
+func fieldTagKey(field reflect.StructField, tagName string) (string, bool) {
+	if key, ok := tagOption(field, "name="); ok && key != "" {
+		return key, true
+	}
+	return tagKey(field, tagName)
+}
+
+// skippedByTag reports whether field is tagged "-" by the tag given to
+// WithTagKey, so Map leaves it out in both directions.
+func skippedByTag(field reflect.StructField, config *Config) bool {
+	return config.strictTagKeys && config.tagName != "" && field.Tag.Get(config.tagName) == "-"
+}
+
+func hasTagKey(field reflect.StructField, config *Config) bool {
+	_, ok := fieldTagKey(field, config.tagName)
+	return ok
+}
+
+// tagKey returns the key set by the tagName tag of field, without its options,
+// if any. Empty keys and "-" don't count.
+func tagKey(field reflect.StructField, tagName string) (string, bool) {
+	if tagName == "" {
+		return "", false
+	}
+	key := field.Tag.Get(tagName)
+	if i := strings.Index(key, ","); i >= 0 {
+		key = key[:i]
+	}
+	if key == "" || key == "-" {
+		return "", false
+	}
+	return key, true
+}
+
+// stringTagged reports whether the tagName tag of the field of the struct type
+// typ with the given name has the string option, like `json:"port,string"`,
+// and the field is a number or a boolean.
+func stringTagged(typ reflect.Type, name, tagName string) bool {
+	if tagName == "" {
+		return false
+	}
+	index, ok := fieldIndexesOf(typ)[name]
+	if !ok {
+		return false
+	}
+	field := typ.FieldByIndex(index)
+	if k := field.Type.Kind(); !isNumberKind(k) && k != reflect.Bool {
+		return false
+	}
+	options := strings.Split(field.Tag.Get(tagName), ",")
+	for _, option := range options[1:] {
+		if option == "string" {
+			return true
+		}
+	}
+	return false
+}
+
+// mapGetters sets in dstMap the values returned by the exported getters of src,
+// methods without arguments returning a single value, or only by the ones named
+// by WithGetters. Their keys follow the same casing as fields' ones, and fields
+// win over getters sharing a key.
+func mapGetters(dst, src reflect.Value, config *Config) {
+	fields := make(map[string]bool, src.NumField())
+	for i, n := 0, src.NumField(); i < n; i++ {
+		if field := src.Type().Field(i); isExported(field) {
+			fields[fieldKey(field, config)] = true
+		}
+	}
+	receiver := src
+	if src.CanAddr() {
+		receiver = src.Addr()
+	}
+	for i, n := 0, receiver.NumMethod(); i < n; i++ {
+		name := receiver.Type().Method(i).Name
+		getter := receiver.Method(i)
+		if getter.Type().NumIn() != 0 || getter.Type().NumOut() != 1 || config.getterNames != nil && !config.getterNames[name] {
+			continue
+		}
+		key := nameKey(name, config)
+		if fields[key] {
+			continue
+		}
+		mapKey := reflect.ValueOf(key).Convert(dst.Type().Key())
+		if v := dst.MapIndex(mapKey); !v.IsValid() || isEmptyValue(v) || config.Overwrite {
+			if value, err := mapElementValue(getter.Call(nil)[0], dst.Type().Elem()); err == nil {
+				dst.SetMapIndex(mapKey, value)
+			}
+		}
+	}
+}
+
+// mapElementValue returns v as a value of elemType, the element type of a map
+// being mapped into. v must be assignable to it, or be a number or string when
+// elemType is another numeric or string type. Booleans and numbers also
+// convert into strings, formatted.
+func mapElementValue(v reflect.Value, elemType reflect.Type) (reflect.Value, error) {
+	if v.Type().AssignableTo(elemType) {
+		return v, nil
+	}
+	srcKind, dstKind := v.Kind(), elemType.Kind()
+	switch {
+	case isNumberKind(srcKind) && isNumberKind(dstKind):
+		return coerceNumber(v, elemType)
+	case srcKind == reflect.String && dstKind == reflect.String:
+		return v.Convert(elemType), nil
+	case dstKind == reflect.String && (srcKind == reflect.Bool || isNumberKind(srcKind)):
+		return reflect.ValueOf(fmt.Sprint(v.Interface())).Convert(elemType), nil
+	}
+	return reflect.Value{}, fmt.Errorf("found %s, expected %s", v.Type(), elemType)
+}
+
+// fieldMismatch returns the mismatch between the dst and src types of the field
+// name, see typeMismatch. The fields of the arguments themselves keep reporting
+// it by name, finding found and expecting expected, as they always did.
+func fieldMismatch(config *Config, name string, dst, src reflect.Type, found, expected interface{}) error {
+	if len(config.path) <= 1 && config.errorFormatter == nil && !config.accumulateErrors && !config.panicOnTypeMismatch {
+		return fmt.Errorf("type mismatch on %s field: found %v, expected %v", name, found, expected)
+	}
+	return typeMismatch(config, ErrDifferentArgumentsTypes, dst, src)
+}
+
 // Traverses recursively both values, assigning src's fields values to dst.
 // The map argument tracks comparisons that have already been seen, which allows
 // short circuiting on recursive types.
-func deepMap(dst, src reflect.Value, visited map[uintptr]*visit, depth int, config *Config) (err error) {
+func deepMap(dst, src reflect.Value, visited map[visit]bool, depth int, config *Config) (err error) {
 	overwrite := config.Overwrite
-	if dst.CanAddr() {
-		addr := dst.UnsafeAddr()
-		h := 17 * addr
-		seen := visited[h]
-		typ := dst.Type()
-		for p := seen; p != nil; p = p.next {
-			if p.ptr == addr && p.typ == typ {
-				return nil
-			}
+	if config.debugDumpOnError {
+		defer dumpOnError(&err, dst, src, config)
+	}
+	if dst.CanAddr() && !config.disableCycleDetection {
+		v := visit{dst.UnsafeAddr(), dst.Type()}
+		if visited[v] {
+			return nil
 		}
 		// Remember, remember...
-		visited[h] = &visit{addr, typ, seen}
+		if err = markVisited(visited, v, config); err != nil {
+			return
+		}
 	}
 	zeroValue := reflect.Value{}
 	switch dst.Kind() {
 	case reflect.Map:
-		dstMap := dst.Interface().(map[string]interface{})
-		for i, n := 0, src.NumField(); i < n; i++ {
-			srcType := src.Type()
-			field := srcType.Field(i)
-			if !isExported(field) {
+		keyType, elemType := dst.Type().Key(), dst.Type().Elem()
+		if keyType.Kind() != reflect.String {
+			return fmt.Errorf("%w: cannot map fields into %s keys", ErrNotSupported, keyType)
+		}
+		if dst.IsNil() {
+			if !dst.CanSet() {
+				return ErrNilArguments
+			}
+			dst.Set(reflect.MakeMap(dst.Type()))
+		}
+		fields := structFieldsOf(src.Type())
+		for i := range fields {
+			if err = countField(config); err != nil {
+				return
+			}
+			field := &fields[i]
+			if !field.exported || (config.ignoreUnexportedTypes && isUnexportedType(field.Type)) || skippedByTag(field.StructField, config) {
 				continue
 			}
-			fieldName := field.Name
-			fieldName = changeInitialCase(fieldName, unicode.ToLower)
-			if v, ok := dstMap[fieldName]; !ok || (isEmptyValue(reflect.ValueOf(v)) || overwrite) {
-				dstMap[fieldName] = src.Field(i).Interface()
+			key := field.mapKey(keyType, config)
+			if elemType == interfaceType {
+				var mapped bool
+				if mapped, err = mapNestedElement(dst, key, src.Field(i), visited, depth, config); err != nil {
+					return
+				} else if mapped {
+					continue
+				}
+			}
+			if v := dst.MapIndex(key); !v.IsValid() || isEmptyValue(v) || overwrite {
+				var transformed bool
+				if transformed, err = transformMapElement(dst, key, src.Field(i), config); err != nil {
+					return
+				} else if !transformed {
+					value, convErr := mapElementValue(src.Field(i), elemType)
+					if convErr != nil {
+						pushField(config, field.Name)
+						err = fieldMismatch(config, field.Name, elemType, field.Type, field.Type, elemType)
+						popPath(config)
+						if err != nil {
+							return
+						}
+						continue
+					}
+					dst.SetMapIndex(key, value)
+				}
 			}
 		}
+		if config.includeGetters {
+			mapGetters(dst, src, config)
+		}
 	case reflect.Ptr:
+		if dst.Type().Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("%w: cannot bind %s to %s", ErrNotSupported, src.Type(), dst.Type())
+		}
 		if dst.IsNil() {
 			v := reflect.New(dst.Type().Elem())
 			dst.Set(v)
@@ -70,35 +247,179 @@ func deepMap(dst, src reflect.Value, visited map[uintptr]*visit, depth int, conf
 		dst = dst.Elem()
 		fallthrough
 	case reflect.Struct:
-		srcMap := src.Interface().(map[string]interface{})
-		for key := range srcMap {
+		if src, err = normalizeMapKeys(src, config); err != nil {
+			return
+		}
+		srcMap, ok := stringKeyedMap(src)
+		if !ok {
+			return fmt.Errorf("%w: cannot bind %s to a struct", ErrNotSupported, src.Type())
+		}
+		aliases := aliasedKeys(srcMap, config)
+		pushField(config, "")
+		for _, key := range stringKeys(srcMap, config) {
+			config.path[len(config.path)-1] = pathElement{field: key}
+			if err = countField(config); err != nil {
+				return
+			}
 			config.overwriteWithEmptyValue = true
 			srcValue := srcMap[key]
-			fieldName := changeInitialCase(key, unicode.ToUpper)
-			dstElement := dst.FieldByName(fieldName)
+			fieldName, aliased := aliases[key]
+			matchKey := key
+			if config.normalizeKey != nil {
+				matchKey = config.normalizeKey(key)
+			}
+			var tagged bool
+			if !aliased {
+				if fieldName, tagged = taggedFieldName(dst.Type(), matchKey, config.tagName); !tagged {
+					fieldName = keyFieldName(dst.Type(), matchKey, config)
+				}
+			}
+			dstElement := fieldByName(dst, fieldName)
+			if dstElement == zeroValue && config.acronyms != nil && !aliased {
+				fieldName = changeInitialCase(matchKey, unicode.ToUpper)
+				dstElement = fieldByName(dst, fieldName)
+			}
+			if _, hasAliases := config.fieldAliases[fieldName]; hasAliases && !aliased {
+				// Only the aliases of a field can bind it.
+				continue
+			}
+			if dstElement != zeroValue && config.strictTagKeys && !aliased {
+				// Fields with a key set by their tags can only be bound by it.
+				if field, ok := dst.Type().FieldByName(fieldName); ok && (skippedByTag(field, config) || !tagged && hasTagKey(field, config)) {
+					dstElement = zeroValue
+				}
+			}
 			if dstElement == zeroValue {
 				// We discard it because the field doesn't exist.
+				if config.unknownKeys != nil {
+					if *config.unknownKeys == nil {
+						*config.unknownKeys = make(map[string]interface{})
+					}
+					(*config.unknownKeys)[sourcePath(config)] = srcValue
+				}
+				continue
+			}
+			// Bound, so paths name the field like Merge does.
+			config.path[len(config.path)-1] = pathElement{field: fieldName, source: key}
+			if config.ignoreUnexportedTypes && isUnexportedType(dstElement.Type()) {
+				continue
+			}
+			if isReadOnlyByName(dst.Type(), fieldName) {
+				traceDecision(config, TraceProtect, dstElement, dstElement)
 				continue
 			}
 			srcElement := reflect.ValueOf(srcValue)
+			if config.skipIf != nil && config.skipIf(currentPath(config), dstElement, srcElement) {
+				traceDecision(config, TraceKeep, dstElement, dstElement)
+				continue
+			}
+			if srcElement.Kind() == reflect.String && stringTagged(dst.Type(), fieldName, config.tagName) {
+				// Like encoding/json, the ,string option holds numbers
+				// and booleans encoded as strings.
+				if srcElement, err = parseFormValue(dstElement.Type(), srcElement.String()); err != nil {
+					return fmt.Errorf("cannot parse %s field: %w", fieldName, err)
+				}
+			}
+			if config.writerFields {
+				var written bool
+				if written, err = writeInto(dstElement, srcElement); err != nil {
+					return
+				} else if written {
+					continue
+				}
+			}
+			if config.binaryUnmarshal {
+				var decoded bool
+				if decoded, err = unmarshalBinaryInto(dstElement, srcElement, overwrite); err != nil {
+					return fmt.Errorf("cannot unmarshal %s field: %w", fieldName, err)
+				} else if decoded {
+					continue
+				}
+			}
+			if config.discriminator != "" {
+				var bound bool
+				if bound, err = mapDiscriminated(dstElement, srcValue, visited, depth, config); err != nil {
+					return
+				} else if bound {
+					continue
+				}
+			}
+			if srcElement.IsValid() {
+				if convert, ok := config.converters[converterKey{srcElement.Type(), dstElement.Type()}]; ok {
+					if srcElement, err = convert(srcElement); err != nil {
+						return fmt.Errorf("cannot convert %s field: %w", fieldName, err)
+					}
+					if srcElement.IsValid() && srcElement.Type() == dstElement.Type() {
+						if overwrite || isEmptyValue(dstElement) || dstElement.Kind() == reflect.Struct && isEmptyStruct(dstElement) {
+							dstElement.Set(srcElement)
+						}
+						continue
+					}
+				}
+			}
 			dstKind := dstElement.Kind()
 			srcKind := srcElement.Kind()
 			if srcKind == reflect.Ptr && dstKind != reflect.Ptr {
-				srcElement = srcElement.Elem()
-				srcKind = reflect.TypeOf(srcElement.Interface()).Kind()
+				if srcElement = srcElement.Elem(); srcElement.IsValid() {
+					srcKind = reflect.TypeOf(srcElement.Interface()).Kind()
+				}
 			} else if dstKind == reflect.Ptr {
-				// Can this work? I guess it can't.
 				if srcKind != reflect.Ptr && srcElement.CanAddr() {
 					srcPtr := srcElement.Addr()
 					srcElement = reflect.ValueOf(srcPtr)
 					srcKind = reflect.Ptr
+				} else if srcKind != reflect.Ptr && srcElement.IsValid() && config.canonicalizePointers {
+					// Map values aren't addressable, so point to a copy.
+					var boxed bool
+					var ptr reflect.Value
+					if ptr, boxed, err = boxValue(dstElement, srcElement, config); err != nil {
+						return
+					} else if boxed {
+						srcElement, srcKind = ptr, reflect.Ptr
+					}
 				}
 			}
 
 			if !srcElement.IsValid() {
+				if config.errorOnInvalidValue {
+					return fmt.Errorf("%w for %s key", ErrInvalidValue, key)
+				}
 				continue
 			}
-			if srcKind == dstKind {
+			if srcElement.Type() == jsonNumberType && isNumberKind(dstKind) {
+				if srcElement, err = parseJSONNumber(json.Number(srcElement.String()), dstElement.Type()); err != nil {
+					return fmt.Errorf("cannot parse %s field: %w", fieldName, err)
+				}
+				srcKind = dstKind
+			}
+			if config.numericCoercion && srcKind != dstKind && isNumberKind(srcKind) && isNumberKind(dstKind) {
+				original := srcElement
+				if srcElement, err = coerceField(srcElement, dstElement.Type(), config); err != nil {
+					return fmt.Errorf("cannot coerce %s field: %w", fieldName, err)
+				}
+				if config.roundTripSafety {
+					if back, err := coerceNumber(srcElement, original.Type()); err != nil || back.Interface() != original.Interface() {
+						return fmt.Errorf("cannot coerce %s field: %v loses precision as %s", fieldName, original, dstElement.Type())
+					}
+				}
+				srcKind = dstKind
+			}
+			if srcKind == dstKind && isScalarKind(srcKind) && srcElement.Type() != dstElement.Type() && srcElement.Type().ConvertibleTo(dstElement.Type()) {
+				// Named scalars, like the wrappers of decoders, bind to
+				// fields of the same kind.
+				srcElement = srcElement.Convert(dstElement.Type())
+			}
+			if config.roundTripSafety && srcElement.Type() == dstElement.Type() {
+				// Assign the value as a whole, so types with unexported fields
+				// like time.Time aren't merged field by field.
+				if overwrite || isEmptyValue(dstElement) || dstKind == reflect.Struct && isEmptyStruct(dstElement) {
+					dstElement.Set(srcElement)
+				}
+			} else if srcElement.Type() != dstElement.Type() && isStructSlice(dstElement) && isMappedStructSlice(srcElement) {
+				if err = bindStructSlice(dstElement, srcElement, visited, depth, config); err != nil {
+					return
+				}
+			} else if srcKind == dstKind {
 				if err = deepMerge(dstElement, srcElement, visited, depth+1, config); err != nil {
 					return
 				}
@@ -110,10 +431,11 @@ func deepMap(dst, src reflect.Value, visited map[uintptr]*visit, depth int, conf
 				if err = deepMap(dstElement, srcElement, visited, depth+1, config); err != nil {
 					return
 				}
-			} else {
-				return fmt.Errorf("type mismatch on %s field: found %v, expected %v", fieldName, srcKind, dstKind)
+			} else if err = fieldMismatch(config, fieldName, dstElement.Type(), srcElement.Type(), srcKind, dstKind); err != nil {
+				return
 			}
 		}
+		popPath(config)
 	}
 	return
 }
@@ -121,7 +443,10 @@ func deepMap(dst, src reflect.Value, visited map[uintptr]*visit, depth int, conf
 // Map sets fields' values in dst from src.
 // src can be a map with string keys or a struct. dst must be the opposite:
 // if src is a map, dst must be a valid pointer to struct. If src is a struct,
-// dst must be map[string]interface{}.
+// dst must be a map with string keys, like map[string]interface{}. Numbers and
+// strings are converted into its element type, booleans and numbers being
+// formatted for string elements. json.Number values, as decoded using
+// UseNumber, are parsed into numeric fields.
 // It won't merge unexported (private) fields and will do recursively
 // any exported field.
 // If dst is a map, keys will be src fields' names in lower camel case.
@@ -140,6 +465,437 @@ func MapWithOverwrite(dst, src interface{}, opts ...func(*Config)) error {
 	return _map(dst, src, append(opts, WithOverride)...)
 }
 
+// KeyValue is a single entry of the ordered output built by MapOrdered.
+type KeyValue struct {
+	Key   string
+	Value interface{}
+}
+
+// MapOrdered maps src, which must be a struct, the same way Map does into an
+// empty map, but returns the entries as an ordered slice. Keys follow the
+// struct declaration order unless WithKeyOrder is used: listed keys come
+// first, in the given order, followed by the rest in declaration order. Keys
+// set by no field, like the ones of WithIncludeGetters, come last, sorted.
+func MapOrdered(src interface{}, opts ...func(*Config)) ([]KeyValue, error) {
+	if src == nil {
+		return nil, ErrNilArguments
+	}
+	vSrc := reflect.ValueOf(src)
+	if vSrc.Kind() == reflect.Ptr {
+		vSrc = vSrc.Elem()
+	}
+	if vSrc.Kind() != reflect.Struct {
+		return nil, ErrNotSupported
+	}
+	values := make(map[string]interface{})
+	config := &Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if err := deepMap(reflect.ValueOf(values), vSrc, make(map[visit]bool), 0, config); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(values))
+	listed := make(map[string]bool, len(config.keyOrder))
+	for _, key := range config.keyOrder {
+		if _, ok := values[key]; ok && !listed[key] {
+			listed[key] = true
+			keys = append(keys, key)
+		}
+	}
+	for i, n := 0, vSrc.NumField(); i < n; i++ {
+		field := vSrc.Type().Field(i)
+		if !isExported(field) || skippedByTag(field, config) {
+			continue
+		}
+		key := fieldKey(field, config)
+		if _, ok := values[key]; ok && !listed[key] {
+			listed[key] = true
+			keys = append(keys, key)
+		}
+	}
+	rest := make([]string, 0, len(values)-len(keys))
+	for key := range values {
+		if !listed[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	keys = append(keys, rest...)
+	entries := make([]KeyValue, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, KeyValue{Key: key, Value: values[key]})
+	}
+	return entries, nil
+}
+
+// MapWithMeta maps src, which must be a struct, the same way Map does into a new
+// map, and also returns the metadata of its fields: the value of their doc tag
+// under the same key. Fields of nested structs are described under their dotted
+// key, like tls.cert. Fields without a doc tag have no metadata.
+func MapWithMeta(src interface{}, opts ...func(*Config)) (map[string]interface{}, map[string]string, error) {
+	if src == nil {
+		return nil, nil, ErrNilArguments
+	}
+	vSrc := reflect.ValueOf(src)
+	if vSrc.Kind() == reflect.Ptr {
+		vSrc = vSrc.Elem()
+	}
+	if vSrc.Kind() != reflect.Struct {
+		return nil, nil, ErrNotSupported
+	}
+	values := make(map[string]interface{})
+	if err := _map(&values, vSrc.Interface(), opts...); err != nil {
+		return nil, nil, err
+	}
+	config := &Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	meta := make(map[string]string)
+	fieldsMeta(meta, vSrc.Type(), "", map[reflect.Type]bool{}, config)
+	return values, meta, nil
+}
+
+// fieldsMeta collects the doc tags of typ fields into meta. seen holds the
+// types being described, so recursive types stop at their first level.
+func fieldsMeta(meta map[string]string, typ reflect.Type, prefix string, seen map[reflect.Type]bool, config *Config) {
+	seen[typ] = true
+	defer delete(seen, typ)
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		field := typ.Field(i)
+		if !isExported(field) || skippedByTag(field, config) {
+			continue
+		}
+		key := prefix + fieldKey(field, config)
+		if doc, ok := field.Tag.Lookup("doc"); ok {
+			meta[key] = doc
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && !seen[fieldType] {
+			fieldsMeta(meta, fieldType, key+".", seen, config)
+		}
+	}
+}
+
+// WithKeyOrder will make MapOrdered emit the given keys first, in that order.
+// Keys not listed follow in struct declaration order.
+func WithKeyOrder(keys []string) func(*Config) {
+	return func(config *Config) {
+		config.keyOrder = keys
+	}
+}
+
+// WithIncludeGetters will make Map also set in the destination map the values
+// returned by src's exported getters, methods like FullName() taking no
+// arguments and returning a single value. Every such method is called, so use
+// WithGetters instead for types with methods like Close() error.
+func WithIncludeGetters(config *Config) {
+	config.includeGetters = true
+}
+
+// WithGetters will make Map include getters like WithIncludeGetters, but only
+// calling the methods with the given names, like FullName.
+func WithGetters(methodNames ...string) func(*Config) {
+	return func(config *Config) {
+		config.includeGetters = true
+		config.getterNames = make(map[string]bool, len(methodNames))
+		for _, name := range methodNames {
+			config.getterNames[name] = true
+		}
+	}
+}
+
+// WithNumericCoercion will make Map convert numbers from src into numeric fields
+// of a different kind, like the float64 values decoded from JSON into int
+// fields. It fails if a value doesn't fit in its field or if a float with a
+// fractional part would be truncated.
+func WithNumericCoercion(config *Config) {
+	config.numericCoercion = true
+}
+
+// aliasedKeys resolves the aliases set by WithFieldAliases against srcMap. It
+// returns the fields bound by the keys that are aliases, the name being empty
+// for the ones superseded by a previous alias of the same field.
+func aliasedKeys(srcMap map[string]interface{}, config *Config) map[string]string {
+	if len(config.fieldAliases) == 0 {
+		return nil
+	}
+	aliases := make(map[string]string)
+	for field, keys := range config.fieldAliases {
+		bound := false
+		for _, key := range keys {
+			if _, ok := srcMap[key]; !ok {
+				continue
+			}
+			if bound {
+				aliases[key] = ""
+			} else {
+				aliases[key] = field
+				bound = true
+			}
+		}
+	}
+	return aliases
+}
+
+// stringKeyedMap returns src, a map with string keys like a named
+// map[string]string type, as a map[string]interface{}.
+func stringKeyedMap(src reflect.Value) (map[string]interface{}, bool) {
+	if src.Kind() != reflect.Map || src.Type().Key().Kind() != reflect.String || !src.CanInterface() {
+		return nil, false
+	}
+	if srcMap, ok := src.Interface().(map[string]interface{}); ok {
+		return srcMap, true
+	}
+	srcMap := make(map[string]interface{}, src.Len())
+	for iter := src.MapRange(); iter.Next(); {
+		srcMap[iter.Key().String()] = iter.Value().Interface()
+	}
+	return srcMap, true
+}
+
+// normalizeMapKeys returns a copy of src, a map with string keys, with all its
+// keys passed through the function given by WithNormalizeKeys. It fails with
+// ErrKeyCollision if two keys normalize to the same one.
+func normalizeMapKeys(src reflect.Value, config *Config) (reflect.Value, error) {
+	if config.normalizeKeys == nil || src.Kind() != reflect.Map || src.Type().Key().Kind() != reflect.String {
+		return src, nil
+	}
+	keys := src.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	normalized := reflect.MakeMapWithSize(src.Type(), len(keys))
+	originals := make(map[string]string, len(keys))
+	for _, key := range keys {
+		name := config.normalizeKeys(key.String())
+		if original, ok := originals[name]; ok {
+			return src, fmt.Errorf("%w: %q and %q both normalize to %q", ErrKeyCollision, original, key.String(), name)
+		}
+		originals[name] = key.String()
+		normalized.SetMapIndex(reflect.ValueOf(name).Convert(src.Type().Key()), src.MapIndex(key))
+	}
+	return normalized, nil
+}
+
+// checkRequiredFields fails if src, the map being bound into dst, has no non-nil
+// value for any of the fields listed by WithRequiredFields. Keys are matched to
+// fields like deepMap does: rewritten by WithNormalizeKeys, then by their tags
+// first.
+func checkRequiredFields(dst, src reflect.Value, config *Config) error {
+	if len(config.requiredFields) == 0 {
+		return nil
+	}
+	src, err := normalizeMapKeys(src, config)
+	if err != nil {
+		return err
+	}
+	srcMap, _ := stringKeyedMap(src)
+	isSet := func(key string) bool {
+		value, ok := srcMap[key]
+		return ok && value != nil && !isReflectNil(reflect.ValueOf(value))
+	}
+	present := make(map[string]bool, len(srcMap))
+	for key := range srcMap {
+		if isSet(key) {
+			for _, field := range requiredFieldNames(dst.Type(), key, config) {
+				present[field] = true
+			}
+		}
+	}
+	for field, keys := range config.fieldAliases {
+		// Only the aliases of a field can bind it.
+		present[field] = false
+		for _, key := range keys {
+			present[field] = present[field] || isSet(key)
+		}
+	}
+	for _, field := range config.requiredFields {
+		if !present[field] {
+			return fmt.Errorf("%w: %s", ErrMissingRequiredField, field)
+		}
+	}
+	return nil
+}
+
+// requiredFieldNames returns the names of the fields of the struct type typ key
+// can bind. Fields with a key set by their tags are only bound by it with
+// WithTagKey.
+func requiredFieldNames(typ reflect.Type, key string, config *Config) []string {
+	if config.normalizeKey != nil {
+		key = config.normalizeKey(key)
+	}
+	if name, ok := taggedFieldName(typ, key, config.tagName); ok {
+		return []string{name}
+	}
+	var names []string
+	for _, name := range []string{keyFieldName(typ, key, config), changeInitialCase(key, unicode.ToUpper)} {
+		if field, ok := typ.FieldByName(name); ok && config.strictTagKeys && (skippedByTag(field, config) || hasTagKey(field, config)) {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// WithCollectUnknownKeys will make Map write into unknown the keys of src not
+// matching any field of dst, along with their values, instead of dropping them.
+// Keys of nested maps bound into nested structs are stored under their dotted
+// path, like tls.extra. unknown is allocated if it is nil.
+func WithCollectUnknownKeys(unknown *map[string]interface{}) func(*Config) {
+	return func(config *Config) {
+		config.unknownKeys = unknown
+	}
+}
+
+// WithFieldAliases will make Map bind a struct field from any of the keys
+// listed for its name, like "addr" and "address" for Address. Keys are checked
+// in the given order and the first present one wins, while keys not listed
+// for the field don't bind it anymore.
+func WithFieldAliases(aliases map[string][]string) func(*Config) {
+	return func(config *Config) {
+		config.fieldAliases = aliases
+	}
+}
+
+// WithRequiredFields will make Map fail with ErrMissingRequiredField when
+// binding a map into a struct if the key of any of the given fields is absent
+// or holds nil. Fields are listed by their name in the struct, and unlike
+// WithSchemaValidation it checks presence in src, not emptiness once merged.
+func WithRequiredFields(fields []string) func(*Config) {
+	return func(config *Config) {
+		config.requiredFields = fields
+	}
+}
+
+// WithSmartCasing will make Map convert between field names and keys word by
+// word, keeping acronyms together: HTTPPort is mapped as httpPort and UserID
+// as userID, while both userID and userId keys bind to UserID. Acronyms are
+// the given ones, or a default set of common ones (API, HTTP, ID, URL...).
+func WithSmartCasing(acronyms ...string) func(*Config) {
+	set := acronymSet(acronyms)
+	return func(config *Config) {
+		config.acronyms = set
+	}
+}
+
+// WithAcronymAwareKeys will make Map lower case the whole leading run of
+// capitals of field names when mapping a struct into a map, so IDField is
+// mapped as idField and HTTPSProxy as httpsProxy, instead of iDField and
+// hTTPSProxy. Runs made of the given acronyms, or of the default ones of
+// WithSmartCasing, are split, so APIURL is mapped as apiURL. Unlike
+// WithSmartCasing, binding map keys to fields is unchanged.
+func WithAcronymAwareKeys(acronyms ...string) func(*Config) {
+	set := acronymSet(acronyms)
+	return func(config *Config) {
+		config.keyAcronyms = set
+	}
+}
+
+// WithSaturatingNumeric will make Map coerce numbers like WithNumericCoercion,
+// but clamp those that don't fit in their field to its min or max instead of
+// failing, like 300 into 127 for an int8. It can't be used along with
+// WithRoundTripSafety, which refuses lossy conversions: Map fails with
+// ErrConflictingOptions.
+func WithSaturatingNumeric(config *Config) {
+	config.numericCoercion = true
+	config.saturatingNumeric = true
+}
+
+// WithRoundTripSafety will make Map keep values intact when mapping a struct
+// into a map and back into a struct of the same type. Values whose type
+// matches their field are assigned as a whole instead of being merged, so
+// types with unexported fields (like time.Time) survive, and with
+// WithNumericCoercion a number that doesn't convert back to the same value
+// (like a big int64 into a float64 field) is an error instead of being
+// rounded. Integers, floats and []byte already keep their Go types in the
+// map. Unexported fields, getters and fields whose keys collide in lower
+// camel case can't round-trip.
+func WithRoundTripSafety(config *Config) {
+	config.roundTripSafety = true
+}
+
+// WithErrorOnInvalidValue will make Map fail with ErrInvalidValue when a src key
+// holds a value that can't be bound, like nil or a nil pointer into a non-pointer
+// field, instead of skipping it.
+func WithErrorOnInvalidValue(config *Config) {
+	config.errorOnInvalidValue = true
+}
+
+// WithTagName will make Map use the keys set by the tagName struct tag, like
+// json, for the fields having one, in both directions. Tag options like
+// omitempty are ignored, but for string, which makes string values be parsed
+// into number and boolean fields. Fields without a tag or tagged "-" keep
+// their default key. Given along with WithTagKey, the last one wins.
+func WithTagName(tagName string) func(*Config) {
+	return func(config *Config) {
+		config.tagName = tagName
+		config.strictTagKeys = false
+	}
+}
+
+// WithTagKey does the same as WithTagName, but strictly: fields with a tag
+// key can't be bound by their default key too, and those tagged "-", like
+// `json:"-"`, are left out entirely in both directions. In both cases, a
+// `mergo:"name=key"` tag takes precedence, and fields without tags keep their
+// default key. Given along with WithTagName, the last one wins.
+func WithTagKey(tagName string) func(*Config) {
+	return func(config *Config) {
+		WithTagName(tagName)(config)
+		config.strictTagKeys = true
+	}
+}
+
+// WithConverter registers convert to bind src values of type from to fields of
+// type to when mapping, like a decoder's date type to time.Time. Converted
+// values are bound as a whole. Other named scalar types are converted into
+// fields of the same kind without one.
+func WithConverter(from, to reflect.Type, convert func(src reflect.Value) (reflect.Value, error)) func(*Config) {
+	return func(config *Config) {
+		if config.converters == nil {
+			config.converters = make(map[converterKey]func(reflect.Value) (reflect.Value, error))
+		}
+		config.converters[converterKey{from, to}] = convert
+	}
+}
+
+// WithUnicodeNormalization will make Map normalize the keys of a map with
+// normalize, like norm.NFC.String from golang.org/x/text/unicode/norm, before
+// matching them to struct fields. Keys differing only by their Unicode
+// composition bind then the same field, which names and tags should be in the
+// same normalization form. Unlike WithNormalizeKeys, keys are normalized only
+// to be matched, and given both, keys are rewritten by WithNormalizeKeys first.
+func WithUnicodeNormalization(normalize func(string) string) func(*Config) {
+	return func(config *Config) {
+		config.normalizeKey = normalize
+	}
+}
+
+// WithNormalizeKeys will make Map and Merge pass every key of src maps with
+// string keys through normalize before matching them with fields or dst keys,
+// like strings.ToLower to bind keys with inconsistent casing. Two src keys
+// normalizing to the same one fail with ErrKeyCollision rather than having one
+// of them win at random. Keys of dst maps are left as they are. The keys
+// rewritten are the ones WithUnicodeNormalization and WithRequiredFields see.
+func WithNormalizeKeys(normalize func(string) string) func(*Config) {
+	return func(config *Config) {
+		config.normalizeKeys = normalize
+	}
+}
+
+// WithDiscriminator will make Map bind a map to an interface field as the
+// variant named by its fieldName key, looked up in registry. The factories of
+// the registry must return pointers to structs, which get the rest of the keys.
+// Binding a different variant to a non-nil field requires WithOverride.
+func WithDiscriminator(fieldName string, registry map[string]func() interface{}) func(*Config) {
+	return func(config *Config) {
+		config.discriminator = fieldName
+		config.discriminatorRegistry = registry
+	}
+}
+
 func _map(dst, src interface{}, opts ...func(*Config)) error {
 	if dst != nil && reflect.ValueOf(dst).Kind() != reflect.Ptr {
 		return ErrNonPointerAgument
@@ -153,14 +909,29 @@ func _map(dst, src interface{}, opts ...func(*Config)) error {
 	for _, opt := range opts {
 		opt(config)
 	}
+	if config.profileConflict {
+		return ErrConflictingProfiles
+	}
+	if config.saturatingNumeric && config.roundTripSafety {
+		return ErrConflictingOptions
+	}
 
 	if vDst, vSrc, err = resolveValues(dst, src); err != nil {
 		return err
 	}
+	if vDst.Kind() == reflect.Slice {
+		return ErrNotSupported
+	}
 	// To be friction-less, we redirect equal-type arguments
 	// to deepMerge. Only because arguments can be anything.
 	if vSrc.Kind() == vDst.Kind() {
-		return deepMerge(vDst, vSrc, make(map[uintptr]*visit), 0, config)
+		if err = deepMerge(vDst, vSrc, make(map[visit]bool), 0, config); err == nil && len(config.defaults) > 0 {
+			err = applyDefaults(vDst, config)
+		}
+		if err == nil && config.schemaValidation {
+			err = validate(vDst, make(map[uintptr]bool), config)
+		}
+		return finishMerge(dst, err, config)
 	}
 	switch vSrc.Kind() {
 	case reflect.Struct:
@@ -174,5 +945,16 @@ func _map(dst, src interface{}, opts ...func(*Config)) error {
 	default:
 		return ErrNotSupported
 	}
-	return deepMap(vDst, vSrc, make(map[uintptr]*visit), 0, config)
+	if vSrc.Kind() == reflect.Map {
+		if err = checkRequiredFields(vDst, vSrc, config); err != nil {
+			return err
+		}
+	}
+	if err = deepMap(vDst, vSrc, make(map[visit]bool), 0, config); err == nil && len(config.defaults) > 0 {
+		err = applyDefaults(vDst, config)
+	}
+	if err == nil && config.schemaValidation {
+		err = validate(vDst, make(map[uintptr]bool), config)
+	}
+	return finishMerge(dst, err, config)
 }
@@ -0,0 +1,59 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type mapDefaults struct {
+	Labels  map[string]string
+	Hosts   map[string][]string
+	Nested  map[string]map[string]int
+	Servers map[string]*layeredTLS
+}
+
+func newMapDefaults() mapDefaults {
+	return mapDefaults{
+		Labels:  map[string]string{"env": "dev"},
+		Hosts:   map[string][]string{"web": {"a", "b"}},
+		Nested:  map[string]map[string]int{"limits": {"cpu": 1}},
+		Servers: map[string]*layeredTLS{"main": {Cert: "main.crt"}},
+	}
+}
+
+func TestMergeEmptyMapsGetOwnMap(t *testing.T) {
+	src := newMapDefaults()
+	dst := mapDefaults{Labels: map[string]string{}}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst, src) {
+		t.Fatalf("expected %v, got %v", src, dst)
+	}
+	dst.Labels["team"] = "core"
+	dst.Hosts["db"] = []string{"c"}
+	if len(src.Labels) != 1 || len(src.Hosts) != 1 {
+		t.Error("expected dst maps not to be the src ones")
+	}
+}
+
+func TestMapDeepCopy(t *testing.T) {
+	src := newMapDefaults()
+	dst := mapDefaults{}
+
+	if err := mergo.Merge(&dst, src, mergo.WithMapDeepCopy); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst, src) {
+		t.Fatalf("expected %v, got %v", src, dst)
+	}
+	dst.Hosts["web"][0] = "changed"
+	dst.Nested["limits"]["cpu"] = 2
+	dst.Servers["main"].Cert = "changed.crt"
+	if !reflect.DeepEqual(src, newMapDefaults()) {
+		t.Errorf("expected src to be isolated from dst, got %v", src)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+var genericMapType = reflect.TypeOf(map[string]interface{}{})
+
+// mapNestedElement maps src, the value of a field, under key into dst, a map of
+// interfaces, when it isn't a plain value: structs become nested generic maps,
+// merged into the one already under key if any, slices of structs become
+// slices of them, and maps are copied. Nil pointers are skipped and others
+// are followed, so dst doesn't share storage with src. It reports whether src
+// was handled.
+func mapNestedElement(dst, key, src reflect.Value, visited map[visit]bool, depth int, config *Config) (bool, error) {
+	value := src
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return true, nil
+		}
+		v := visit{value.Pointer(), value.Type()}
+		if visited[v] {
+			// A cycle, already being mapped.
+			return true, nil
+		}
+		if err := markVisited(visited, v, config); err != nil {
+			return true, err
+		}
+		defer delete(visited, v)
+		value = value.Elem()
+	}
+	current := dst.MapIndex(key)
+	keep := current.IsValid() && !isEmptyValue(current) && !config.Overwrite
+	switch {
+	case isMappableStruct(value):
+		if held := unwrapInterface(current); held.IsValid() && held.Type() == genericMapType && !held.IsNil() {
+			return true, deepMap(held, value, visited, depth+1, config)
+		}
+		if keep {
+			return true, nil
+		}
+		nested := reflect.MakeMap(genericMapType)
+		if err := deepMap(nested, value, visited, depth+1, config); err != nil {
+			return true, err
+		}
+		dst.SetMapIndex(key, nested)
+	case value.Kind() == reflect.Slice && isStructSlice(value):
+		if keep {
+			return true, nil
+		}
+		elements := reflect.MakeSlice(reflect.TypeOf([]interface{}{}), value.Len(), value.Len())
+		for i, n := 0, value.Len(); i < n; i++ {
+			element := reflect.Indirect(value.Index(i))
+			if !isMappableStruct(element) {
+				if element.IsValid() {
+					elements.Index(i).Set(element)
+				}
+				continue
+			}
+			nested := reflect.MakeMap(genericMapType)
+			if err := deepMap(nested, element, visited, depth+1, config); err != nil {
+				return true, err
+			}
+			elements.Index(i).Set(nested)
+		}
+		dst.SetMapIndex(key, elements)
+	case value.Kind() == reflect.Map || value != src:
+		if keep {
+			return true, nil
+		}
+		dst.SetMapIndex(key, deepCopy(value, make(map[copyKey]reflect.Value)))
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// isMappableStruct reports whether v is a struct with exported fields, so it
+// can be mapped into a generic map. Others, like time.Time, are kept as values.
+func isMappableStruct(v reflect.Value) bool {
+	return v.Kind() == reflect.Struct && hasMergeableFields(v)
+}
+
+// isMappedStructSlice reports whether v is a slice of interfaces holding maps,
+// and maybe nils, like the ones mapNestedElement makes of slices of structs.
+func isMappedStructSlice(v reflect.Value) bool {
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.Interface {
+		return false
+	}
+	maps := false
+	for i, n := 0, v.Len(); i < n; i++ {
+		if element := v.Index(i); element.IsNil() {
+			continue
+		} else if element.Elem().Kind() != reflect.Map {
+			return false
+		}
+		maps = true
+	}
+	return maps
+}
+
+// bindStructSlice sets dst, a slice of structs (or pointers to structs), to the
+// elements of src, a slice of maps, each bound into a struct like Map does,
+// when dst is empty or overwrite is set. Nil elements stay zero.
+func bindStructSlice(dst, src reflect.Value, visited map[visit]bool, depth int, config *Config) error {
+	if !isEmptyValue(dst) && !config.Overwrite {
+		traceDecision(config, TraceKeep, dst, dst)
+		return nil
+	}
+	bound := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+	for i, n := 0, src.Len(); i < n; i++ {
+		element := src.Index(i)
+		if element.IsNil() {
+			continue
+		}
+		pushIndex(config, i)
+		err := deepMap(bound.Index(i), element.Elem(), visited, depth+1, config)
+		popPath(config)
+		if err != nil {
+			return err
+		}
+	}
+	traceDecision(config, TraceSet, dst, bound)
+	dst.Set(bound)
+	return nil
+}
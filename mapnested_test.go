@@ -0,0 +1,122 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type nestedAddress struct {
+	City   string
+	Street string
+}
+
+type nestedPerson struct {
+	Name      string
+	Home      nestedAddress
+	Work      *nestedAddress
+	Previous  []nestedAddress
+	Labels    map[string]string
+	Manager   *nestedPerson
+	Secondary *nestedAddress
+}
+
+func TestMapNestedStructs(t *testing.T) {
+	src := nestedPerson{
+		Name:     "ana",
+		Home:     nestedAddress{City: "Lisbon"},
+		Work:     &nestedAddress{City: "Porto", Street: "Main"},
+		Previous: []nestedAddress{{City: "Braga"}},
+		Labels:   map[string]string{"team": "core"},
+	}
+	dst := map[string]interface{}{}
+	if err := mergo.Map(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"name":     "ana",
+		"home":     map[string]interface{}{"city": "Lisbon", "street": ""},
+		"work":     map[string]interface{}{"city": "Porto", "street": "Main"},
+		"previous": []interface{}{map[string]interface{}{"city": "Braga", "street": ""}},
+		"labels":   map[string]string{"team": "core"},
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+	src.Labels["team"] = "changed"
+	if dst["labels"].(map[string]string)["team"] != "core" {
+		t.Errorf("expected labels to be copied, got %v", dst["labels"])
+	}
+}
+
+func TestMapNestedStructsIntoExistingMaps(t *testing.T) {
+	src := nestedPerson{Home: nestedAddress{City: "Lisbon", Street: "Main"}}
+	dst := map[string]interface{}{
+		"home": map[string]interface{}{"city": "Madrid", "zip": "28001"},
+	}
+	if err := mergo.Map(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"city": "Madrid", "street": "Main", "zip": "28001"}
+	if !reflect.DeepEqual(dst["home"], expected) {
+		t.Errorf("expected %v, got %v", expected, dst["home"])
+	}
+	if err := mergo.Map(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	expected["city"] = "Lisbon"
+	if !reflect.DeepEqual(dst["home"], expected) {
+		t.Errorf("expected %v with override, got %v", expected, dst["home"])
+	}
+}
+
+func TestMapNestedStructsCycle(t *testing.T) {
+	src := &nestedPerson{Name: "ana"}
+	src.Manager = src
+	dst := map[string]interface{}{}
+	if err := mergo.Map(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	manager, ok := dst["manager"].(map[string]interface{})
+	if !ok || manager["name"] != "ana" {
+		t.Errorf("expected manager to be mapped once, got %v", dst["manager"])
+	}
+	if _, ok := manager["manager"]; ok {
+		t.Errorf("expected the cycle to stop, got %v", manager["manager"])
+	}
+}
+
+type nestedRule struct {
+	Name string `json:"name"`
+	Port int    `json:"port,omitempty"`
+}
+
+type nestedRuleConfig struct {
+	Name     string
+	Rules    []nestedRule
+	Pointers []*nestedRule
+	Primary  nestedRule
+}
+
+func TestMapNestedStructsRoundTrip(t *testing.T) {
+	src := nestedRuleConfig{
+		Name:     "api",
+		Rules:    []nestedRule{{Name: "http", Port: 80}, {Name: "https", Port: 443}},
+		Pointers: []*nestedRule{{Name: "admin", Port: 8080}, nil},
+		Primary:  nestedRule{Name: "main", Port: 1},
+	}
+	for _, opts := range [][]func(*mergo.Config){nil, {mergo.WithRoundTripSafety}, {mergo.WithTagName("json")}} {
+		m := map[string]interface{}{}
+		if err := mergo.Map(&m, src, opts...); err != nil {
+			t.Fatal(err)
+		}
+		var back nestedRuleConfig
+		if err := mergo.Map(&back, m, opts...); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(back, src) {
+			t.Errorf("expected %+v after the round trip, got %+v", src, back)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func newContainers() map[string]interface{} {
+	return map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"id": "a", "image": "nginx"},
+			map[string]interface{}{"id": "b", "image": "redis"},
+		},
+	}
+}
+
+func TestMapSliceMergeKey(t *testing.T) {
+	dst := newContainers()
+	src := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"id": "b", "image": "redis:6", "port": 6379},
+			map[string]interface{}{"id": "c", "image": "postgres"},
+			map[string]interface{}{"image": "anonymous"},
+		},
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithMapSliceMergeKey("id")); err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{
+		map[string]interface{}{"id": "a", "image": "nginx"},
+		map[string]interface{}{"id": "b", "image": "redis", "port": 6379},
+		map[string]interface{}{"id": "c", "image": "postgres"},
+		map[string]interface{}{"image": "anonymous"},
+	}
+	if !reflect.DeepEqual(dst["containers"], expected) {
+		t.Errorf("expected %v, got %v", expected, dst["containers"])
+	}
+}
+
+func TestMapSliceMergeKeyWithOverride(t *testing.T) {
+	dst := newContainers()
+	src := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"id": "a", "image": "nginx:1.21"},
+		},
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithMapSliceMergeKey("id")); err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{
+		map[string]interface{}{"id": "a", "image": "nginx:1.21"},
+		map[string]interface{}{"id": "b", "image": "redis"},
+	}
+	if !reflect.DeepEqual(dst["containers"], expected) {
+		t.Errorf("expected %v, got %v", expected, dst["containers"])
+	}
+}
+
+func TestMapSliceMergeKeyStructField(t *testing.T) {
+	type manifest struct {
+		Items []map[string]interface{}
+	}
+	dst := manifest{Items: []map[string]interface{}{{"id": 1, "name": "one"}}}
+	src := manifest{Items: []map[string]interface{}{{"id": 1, "size": 10}, {"id": 2, "name": "two"}}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithMapSliceMergeKey("id")); err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]interface{}{{"id": 1, "name": "one", "size": 10}, {"id": 2, "name": "two"}}
+	if !reflect.DeepEqual(dst.Items, expected) {
+		t.Errorf("expected %v, got %v", expected, dst.Items)
+	}
+}
+
+func TestMapSliceMergeKeyTombstone(t *testing.T) {
+	dst := newContainers()
+	src := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"id": "a", "deleted": true},
+			map[string]interface{}{"id": "b", "port": 6379, "deleted": false},
+			map[string]interface{}{"id": "c", "image": "postgres"},
+			map[string]interface{}{"id": "d", "deleted": true},
+		},
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithMapSliceMergeKey("id"), mergo.WithSliceMergeByKeyTombstone("deleted")); err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{
+		map[string]interface{}{"id": "b", "image": "redis", "port": 6379, "deleted": false},
+		map[string]interface{}{"id": "c", "image": "postgres"},
+	}
+	if !reflect.DeepEqual(dst["containers"], expected) {
+		t.Errorf("expected %v, got %v", expected, dst["containers"])
+	}
+}
@@ -0,0 +1,57 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type storedService struct {
+	Image    string
+	Replicas int
+	Env      map[string]string
+}
+
+func TestMergeStructsStoredInMap(t *testing.T) {
+	dst := map[string]storedService{
+		"web": {Image: "web:1", Env: map[string]string{"LOG": "info"}},
+		"db":  {Image: "db:1", Replicas: 1},
+	}
+	src := map[string]storedService{
+		"web":   {Replicas: 3, Env: map[string]string{"PORT": "80"}},
+		"db":    {Image: "db:2"},
+		"cache": {Image: "cache:1"},
+	}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]storedService{
+		"web":   {Image: "web:1", Replicas: 3, Env: map[string]string{"LOG": "info", "PORT": "80"}},
+		"db":    {Image: "db:1", Replicas: 1},
+		"cache": {Image: "cache:1"},
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if db := dst["db"]; db.Image != "db:2" || db.Replicas != 1 {
+		t.Errorf("expected the db image to be overridden and its replicas kept, got %+v", db)
+	}
+}
+
+func TestMergeStructsStoredInInterfaceMap(t *testing.T) {
+	dst := map[string]interface{}{"web": storedService{Image: "web:1"}}
+	src := map[string]interface{}{"web": storedService{Replicas: 3}}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (storedService{Image: "web:1", Replicas: 3}); !reflect.DeepEqual(dst["web"], expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst["web"])
+	}
+}
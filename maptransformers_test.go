@@ -0,0 +1,70 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/imdario/mergo"
+)
+
+// utcTransformer stores every merged time.Time in UTC, keeping the latest one.
+func utcTransformer() mergo.Transformers {
+	return &transformer{
+		m: map[reflect.Type]func(dst, src reflect.Value) error{
+			reflect.TypeOf(time.Time{}): func(dst, src reflect.Value) error {
+				t := src.Interface().(time.Time)
+				if current := dst.Interface().(time.Time); current.After(t) {
+					t = current
+				}
+				dst.Set(reflect.ValueOf(t.UTC()))
+				return nil
+			},
+		},
+	}
+}
+
+func TestMapValueTransformers(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	older := time.Date(2020, 1, 1, 12, 0, 0, 0, loc)
+	newer := time.Date(2021, 1, 1, 12, 0, 0, 0, loc)
+
+	dst := map[string]interface{}{
+		"created": newer,
+		"nested":  map[string]interface{}{"updated": older},
+	}
+	src := map[string]interface{}{
+		"created": older,
+		"nested":  map[string]interface{}{"updated": newer, "expires": newer},
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithTransformers(utcTransformer())); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"created": newer.UTC(),
+		"nested":  map[string]interface{}{"updated": newer.UTC(), "expires": newer.UTC()},
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestMapValueTransformersStructToMap(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	src := struct {
+		Name    string
+		Created time.Time
+	}{"app", time.Date(2021, 1, 1, 12, 0, 0, 0, loc)}
+	dst := map[string]interface{}{}
+
+	if err := mergo.Map(&dst, src, mergo.WithTransformers(utcTransformer())); err != nil {
+		t.Fatal(err)
+	}
+	if created := dst["created"].(time.Time); created.Location() != time.UTC || !created.Equal(src.Created) {
+		t.Errorf("expected created to be transformed to UTC, got %v", created)
+	}
+	if dst["name"] != "app" {
+		t.Errorf("expected name to be mapped as is, got %v", dst["name"])
+	}
+}
@@ -0,0 +1,71 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type poolMember struct {
+	ID     string
+	Weight int
+}
+
+func TestMergeMapValueSlicesByKey(t *testing.T) {
+	dst := map[string][]poolMember{
+		"web": {{ID: "a", Weight: 1}, {ID: "b"}},
+		"db":  {{ID: "x"}},
+	}
+	src := map[string][]poolMember{
+		"web":   {{ID: "b", Weight: 2}, {ID: "c", Weight: 3}},
+		"cache": {{ID: "y"}},
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceMergeByKeys("ID")); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]poolMember{
+		"web":   {{ID: "a", Weight: 1}, {ID: "b", Weight: 2}, {ID: "c", Weight: 3}},
+		"db":    {{ID: "x"}},
+		"cache": {{ID: "y"}},
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestMergeNestedMapValueSlicesByKey(t *testing.T) {
+	type pools struct {
+		Members map[string][]poolMember
+	}
+	dst := pools{Members: map[string][]poolMember{"web": {{ID: "a"}}}}
+	src := pools{Members: map[string][]poolMember{"web": {{ID: "a", Weight: 1}, {ID: "b"}}}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceMergeByKeys("ID")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []poolMember{{ID: "a", Weight: 1}, {ID: "b"}}; !reflect.DeepEqual(dst.Members["web"], expected) {
+		t.Errorf("expected %v, got %v", expected, dst.Members["web"])
+	}
+
+	boxed := map[string]interface{}{"web": []poolMember{{ID: "a"}}}
+	if err := mergo.Merge(&boxed, map[string]interface{}{"web": []poolMember{{ID: "a", Weight: 1}}}, mergo.WithSliceMergeByKeys("ID")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []poolMember{{ID: "a", Weight: 1}}; !reflect.DeepEqual(boxed["web"], expected) {
+		t.Errorf("expected %v, got %v", expected, boxed["web"])
+	}
+}
+
+func TestMergeMapValueSlicesDeepCopy(t *testing.T) {
+	dst := map[string][]poolMember{"web": {{ID: "a"}, {ID: "b", Weight: 2}}}
+	src := map[string][]poolMember{"web": {{Weight: 1}, {ID: "c"}}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepCopy); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []poolMember{{ID: "a", Weight: 1}, {ID: "c", Weight: 2}}; !reflect.DeepEqual(dst["web"], expected) {
+		t.Errorf("expected elements to be merged by index, got %v", dst["web"])
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+// deepMergeByTag merges the struct src into the struct dst of a different type,
+// pairing their fields by the value of their config.matchTag tag, see
+// WithMatchByTag.
+func deepMergeByTag(dst, src reflect.Value, visited map[visit]bool, depth int, config *Config) error {
+	srcType := src.Type()
+	for i, n := 0, srcType.NumField(); i < n; i++ {
+		field := srcType.Field(i)
+		tag, ok := tagKey(field, config.matchTag)
+		if !ok && field.Anonymous {
+			if err := deepMergeEmbedded(dst, src.Field(i), field, visited, depth, config); err != nil {
+				return err
+			}
+			continue
+		}
+		if !ok || !isExported(field) {
+			continue
+		}
+		name, ok := taggedFieldName(dst.Type(), tag, config.matchTag)
+		if !ok {
+			// We discard it because the field doesn't exist.
+			continue
+		}
+		dstField := fieldByName(dst, name)
+		if !dstField.CanSet() {
+			continue
+		}
+		if isReadOnlyByName(dst.Type(), name) {
+			pushField(config, name)
+			traceDecision(config, TraceProtect, dstField, dstField)
+			popPath(config)
+			continue
+		}
+		if err := countField(config); err != nil {
+			return err
+		}
+		pushField(config, name)
+		err := deepMergeTaggedField(dstField, src.Field(i), visited, depth+1, config)
+		popPath(config)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deepMergeEmbedded merges src, the untagged embedded field of a struct paired
+// by deepMergeByTag, into the field of dst embedding the same type, if any, as
+// a unit.
+func deepMergeEmbedded(dst, src reflect.Value, field reflect.StructField, visited map[visit]bool, depth int, config *Config) error {
+	typ := field.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	dstType := dst.Type()
+	for i, n := 0, dstType.NumField(); i < n; i++ {
+		dstField := dstType.Field(i)
+		embedded := dstField.Type
+		if embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+		if !dstField.Anonymous || embedded != typ || !dst.Field(i).CanSet() {
+			continue
+		}
+		if isReadOnly(dstField) {
+			return nil
+		}
+		pushField(config, dstField.Name)
+		err := deepMergeTaggedField(dst.Field(i), src, visited, depth+1, config)
+		popPath(config)
+		return err
+	}
+	return nil
+}
+
+// deepMergeTaggedField merges the src field into the dst one paired by
+// deepMergeByTag. A pointer on either side, but not the other, is merged
+// through the value it points to, allocating a nil dst one if needed.
+func deepMergeTaggedField(dst, src reflect.Value, visited map[visit]bool, depth int, config *Config) error {
+	if src.Kind() == reflect.Ptr && dst.Type() != src.Type() {
+		if src.IsNil() {
+			return nil
+		}
+		src = src.Elem()
+	}
+	if dst.Kind() == reflect.Ptr && dst.Type() != src.Type() {
+		if dst.IsNil() {
+			if isEmptyElement(src) {
+				return nil
+			}
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+	if dst.Type() != src.Type() && (dst.Kind() != reflect.Struct || src.Kind() != reflect.Struct) {
+		return typeMismatch(config, ErrDifferentArgumentsTypes, dst.Type(), src.Type())
+	}
+	return deepMerge(dst, src, visited, depth, config)
+}
@@ -0,0 +1,157 @@
+package mergo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type userAddressDTO struct {
+	Town string `json:"city"`
+}
+
+type userDTO struct {
+	UserName string         `json:"name,omitempty"`
+	Mail     string         `json:"email"`
+	Home     userAddressDTO `json:"address"`
+	Internal string         `json:"-"`
+}
+
+type userAddress struct {
+	City string `json:"city"`
+}
+
+type userModel struct {
+	Name     string      `json:"name"`
+	Email    string      `json:"email"`
+	Address  userAddress `json:"address"`
+	Internal string
+}
+
+func TestMatchByTag(t *testing.T) {
+	dst := userModel{Name: "dst"}
+	src := userDTO{UserName: "src", Mail: "src@example.com", Home: userAddressDTO{Town: "Madrid"}, Internal: "secret"}
+
+	if err := mergo.Merge(&dst, src, mergo.WithMatchByTag("json")); err != nil {
+		t.Fatal(err)
+	}
+	expected := userModel{Name: "dst", Email: "src@example.com", Address: userAddress{City: "Madrid"}}
+	if dst != expected {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithMatchByTag("json"), mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "src" {
+		t.Errorf("expected name to be overridden, got %+v", dst)
+	}
+
+	if err := mergo.Merge(&dst, src); !errors.Is(err, mergo.ErrDifferentArgumentsTypes) {
+		t.Errorf("expected ErrDifferentArgumentsTypes without the option, got %v", err)
+	}
+}
+
+func TestMatchByTagMismatch(t *testing.T) {
+	type dstType struct {
+		Port int `json:"port"`
+	}
+	type srcType struct {
+		Port string `json:"port"`
+	}
+	var dst dstType
+
+	if err := mergo.Merge(&dst, srcType{Port: "80"}, mergo.WithMatchByTag("json")); !errors.Is(err, mergo.ErrDifferentArgumentsTypes) {
+		t.Errorf("expected ErrDifferentArgumentsTypes, got %v", err)
+	}
+}
+
+type pointerAddress struct {
+	City string `json:"city"`
+}
+
+type pointerUser struct {
+	Name    *string         `json:"name"`
+	Address *pointerAddress `json:"address"`
+}
+
+type valueUser struct {
+	Name    string      `json:"name"`
+	Address userAddress `json:"address"`
+}
+
+func TestMatchByTagPointersIntoValues(t *testing.T) {
+	name := "src"
+	dst := valueUser{Address: userAddress{City: "dst"}}
+	src := pointerUser{Name: &name, Address: &pointerAddress{City: "src"}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithMatchByTag("json")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (valueUser{Name: "src", Address: userAddress{City: "dst"}}); dst != expected {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+
+	if err := mergo.Merge(&dst, pointerUser{}, mergo.WithMatchByTag("json"), mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "src" {
+		t.Errorf("expected nil pointers to be skipped, got %+v", dst)
+	}
+}
+
+func TestMatchByTagValuesIntoPointers(t *testing.T) {
+	var dst pointerUser
+	src := valueUser{Name: "src", Address: userAddress{City: "src"}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithMatchByTag("json")); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name == nil || *dst.Name != "src" || dst.Address == nil || dst.Address.City != "src" {
+		t.Errorf("expected pointers to be allocated and merged, got %+v", dst)
+	}
+
+	dst = pointerUser{}
+	if err := mergo.Merge(&dst, valueUser{}, mergo.WithMatchByTag("json")); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != nil || dst.Address != nil {
+		t.Errorf("expected empty values not to allocate pointers, got %+v", dst)
+	}
+}
+
+type RecordBase struct {
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+}
+
+type baseRequest struct {
+	RecordBase
+	Title string `json:"title"`
+}
+
+type baseRecord struct {
+	*RecordBase
+	Name string `json:"title"`
+}
+
+func TestMatchByTagSharedEmbedded(t *testing.T) {
+	dst := baseRecord{RecordBase: &RecordBase{Version: 1}}
+	src := baseRequest{RecordBase: RecordBase{ID: "src", Version: 2}, Title: "src"}
+
+	if err := mergo.Merge(&dst, src, mergo.WithMatchByTag("json")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (RecordBase{ID: "src", Version: 1}); *dst.RecordBase != expected || dst.Name != "src" {
+		t.Errorf("expected the embedded base to be merged, got %+v %+v", *dst.RecordBase, dst)
+	}
+
+	out := baseRequest{}
+	if err := mergo.Merge(&out, dst, mergo.WithMatchByTag("json")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (baseRequest{RecordBase: RecordBase{ID: "src", Version: 1}, Title: "src"}); out != expected {
+		t.Errorf("expected %+v, got %+v", expected, out)
+	}
+}
@@ -0,0 +1,64 @@
+package mergo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type blobs struct {
+	Chunks [][]int64
+	Index  map[string][]int64
+}
+
+func newBlobs(n int) blobs {
+	b := blobs{Index: map[string][]int64{}}
+	for i := 0; i < n; i++ {
+		b.Chunks = append(b.Chunks, make([]int64, 100))
+	}
+	b.Index["all"] = make([]int64, n*100)
+	return b
+}
+
+func TestMaxBytes(t *testing.T) {
+	src := newBlobs(10)
+
+	// The index alone takes 8000 bytes to copy.
+	dst := blobs{}
+	err := mergo.Merge(&dst, src, mergo.WithMapDeepCopy, mergo.WithMaxBytes(4000))
+	if !errors.Is(err, mergo.ErrByteBudgetExceeded) {
+		t.Fatalf("expected %v, got %v", mergo.ErrByteBudgetExceeded, err)
+	}
+
+	dst = blobs{}
+	if err := mergo.Merge(&dst, src, mergo.WithMapDeepCopy, mergo.WithMaxBytes(1<<20)); err != nil {
+		t.Fatalf("expected the copy to fit in the budget, got %v", err)
+	}
+}
+
+func TestMaxBytesAppend(t *testing.T) {
+	dst := newBlobs(1)
+	src := newBlobs(10)
+
+	// Appending takes 10 slice headers of 24 bytes for the chunks, and
+	// 1000 int64 for the index.
+	err := mergo.Merge(&dst, src, mergo.WithAppendSlice, mergo.WithMaxBytes(8000))
+	if !errors.Is(err, mergo.ErrByteBudgetExceeded) {
+		t.Fatalf("expected %v, got %v", mergo.ErrByteBudgetExceeded, err)
+	}
+	if err := mergo.Merge(&dst, src, mergo.WithAppendSlice, mergo.WithMaxBytes(8240)); err != nil {
+		t.Fatalf("expected the append to fit in the budget, got %v", err)
+	}
+}
+
+func TestMaxBytesMergeTo(t *testing.T) {
+	out := blobs{}
+	err := mergo.MergeTo(&out, newBlobs(10), blobs{}, mergo.WithMaxBytes(8000))
+	if !errors.Is(err, mergo.ErrByteBudgetExceeded) {
+		t.Fatalf("expected %v, got %v", mergo.ErrByteBudgetExceeded, err)
+	}
+	if err := mergo.MergeTo(&out, newBlobs(10), blobs{}); err != nil {
+		t.Fatal(err)
+	}
+}
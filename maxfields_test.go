@@ -0,0 +1,62 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type maxFieldsInner struct {
+	A, B int
+}
+
+type maxFieldsOuter struct {
+	Name  string
+	Port  int
+	Inner maxFieldsInner
+}
+
+func TestMaxFieldsStruct(t *testing.T) {
+	src := maxFieldsOuter{Name: "src", Port: 1, Inner: maxFieldsInner{1, 2}}
+
+	// 3 fields at the top level plus 2 in Inner.
+	dst := maxFieldsOuter{}
+	if err := mergo.Merge(&dst, src, mergo.WithMaxFields(5)); err != nil {
+		t.Errorf("expected no error at the limit, got %v", err)
+	}
+	if dst != src {
+		t.Errorf("expected %v, got %v", src, dst)
+	}
+	dst = maxFieldsOuter{}
+	if err := mergo.Merge(&dst, src, mergo.WithMaxFields(4)); err != mergo.ErrTooManyFields {
+		t.Errorf("expected %v beyond the limit, got %v", mergo.ErrTooManyFields, err)
+	}
+}
+
+func TestMaxFieldsMap(t *testing.T) {
+	src := map[string]interface{}{
+		"a": 1,
+		"b": map[string]interface{}{"c": 2, "d": 3},
+	}
+
+	dst := map[string]interface{}{"b": map[string]interface{}{}}
+	if err := mergo.Merge(&dst, src, mergo.WithMaxFields(4)); err != nil {
+		t.Errorf("expected no error at the limit, got %v", err)
+	}
+	dst = map[string]interface{}{"b": map[string]interface{}{}}
+	if err := mergo.Merge(&dst, src, mergo.WithMaxFields(3)); err != mergo.ErrTooManyFields {
+		t.Errorf("expected %v beyond the limit, got %v", mergo.ErrTooManyFields, err)
+	}
+}
+
+func TestMaxFieldsMapToStruct(t *testing.T) {
+	src := map[string]interface{}{"name": "src", "port": 1}
+
+	dst := maxFieldsOuter{}
+	if err := mergo.Map(&dst, src, mergo.WithMaxFields(2)); err != nil {
+		t.Errorf("expected no error at the limit, got %v", err)
+	}
+	if err := mergo.Map(&dst, src, mergo.WithMaxFields(1)); err != mergo.ErrTooManyFields {
+		t.Errorf("expected %v beyond the limit, got %v", mergo.ErrTooManyFields, err)
+	}
+}
@@ -0,0 +1,34 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type visitedNode struct {
+	Value int
+	Next  *visitedNode
+}
+
+func visitedList(n int) *visitedNode {
+	var head *visitedNode
+	for i := n; i > 0; i-- {
+		head = &visitedNode{Value: i, Next: head}
+	}
+	return head
+}
+
+func TestMaxVisitedEntries(t *testing.T) {
+	// Every node tracks itself, its Value and its Next field.
+	dst := visitedNode{Next: visitedList(3)}
+	src := visitedNode{Value: 1, Next: visitedList(3)}
+	if err := mergo.Merge(&dst, src, mergo.WithMaxVisitedEntries(12)); err != nil {
+		t.Errorf("expected no error at the limit, got %v", err)
+	}
+
+	dst = visitedNode{Next: visitedList(3)}
+	if err := mergo.Merge(&dst, src, mergo.WithMaxVisitedEntries(11)); err != mergo.ErrTooManyNodes {
+		t.Errorf("expected %v beyond the limit, got %v", mergo.ErrTooManyNodes, err)
+	}
+}
@@ -9,20 +9,41 @@
 package mergo
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
-func hasMergeableFields(dst reflect.Value) (exported bool) {
-	for i, n := 0, dst.NumField(); i < n; i++ {
-		field := dst.Type().Field(i)
-		if field.Anonymous && dst.Field(i).Kind() == reflect.Struct {
-			exported = exported || hasMergeableFields(dst.Field(i))
-		} else if isExportedComponent(&field) {
-			exported = exported || len(field.PkgPath) == 0
+func hasMergeableFields(dst reflect.Value) bool {
+	return structTypeOf(dst.Type()).mergeable
+}
+
+// isUnexportedType reports whether typ, or the type it is built from for
+// pointers, slices, arrays, channels and maps, is a named type unexported by
+// its package.
+func isUnexportedType(typ reflect.Type) bool {
+	for typ.Name() == "" {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Chan:
+			typ = typ.Elem()
+		case reflect.Map:
+			if isUnexportedType(typ.Key()) {
+				return true
+			}
+			typ = typ.Elem()
+		default:
+			return false
 		}
 	}
-	return
+	if typ.PkgPath() == "" {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(typ.Name())
+	return !unicode.IsUpper(r)
 }
 
 func isExportedComponent(field *reflect.StructField) bool {
@@ -37,15 +58,136 @@ func isExportedComponent(field *reflect.StructField) bool {
 	return true
 }
 
+// Config holds the options of a merge. The exported fields can be set directly,
+// while the rest are set by the With* options, which can also be applied to a
+// Config built by hand:
+//
+//	config := &mergo.Config{AppendSlice: true}
+//	mergo.WithOverride(config)
+//	mergo.WithMaxFields(1000)(config)
+//
+// Such a Config is used by MergeWithConfig.
 type Config struct {
-	Overwrite                    bool
-	AppendSlice                  bool
-	TypeCheck                    bool
-	Transformers                 Transformers
+	// Overwrite makes non-empty src values override dst ones, see WithOverride.
+	Overwrite bool
+	// AppendSlice appends src slices to dst ones, see WithAppendSlice.
+	AppendSlice bool
+	// TypeCheck fails when overriding slices of different types, see WithTypeCheck.
+	TypeCheck bool
+	// Transformers customizes the merge of some types, see WithTransformers.
+	Transformers Transformers
+
 	overwriteWithEmptyValue      bool
 	overwriteSliceWithEmptyValue bool
 	sliceDeepCopy                bool
+	emptyStringElementsAbsent    bool
+	mapDeepCopy                  bool
+	deepCopy                     bool
+	mergeOnlyIfNil               bool
+	skipZeroTimeOverwrite        bool
+	overrideTypes                map[reflect.Type]bool
+	shouldOverwrite              func(path string, dst, src reflect.Value) bool
+	skipIf                       func(path string, dst, src reflect.Value) bool
+	clearMarkers                 map[reflect.Type]interface{}
+	onAppend                     func(path string, appended reflect.Value)
+	namedTransforms              map[string]func(reflect.Value) reflect.Value
+	typeTransformers             map[reflect.Type]func(dst, src reflect.Value) error
+	interfaceTransformers        []interfaceTransformer
+	kindTransformers             map[reflect.Kind]func(dst, src reflect.Value) error
+	ctx                          context.Context
+	srcCache                     *MergeCache
+	mostCompleteWins             bool
+	finalizer                    func(dst interface{}) error
+	strictTagKeys                bool
+	mergeStrategyTag             bool
+	onlyEmbedded                 map[string]bool
+	typeHooks                    map[reflect.Type]typeHook
+	scope                        map[interface{}]interface{}
+	writerFields                 bool
+	binaryUnmarshal              bool
+	iterativeMapMerge            bool
+	keyOrder                     []string
+	includeGetters               bool
+	getterNames                  map[string]bool
+	numericCoercion              bool
+	saturatingNumeric            bool
+	converters                   map[converterKey]func(reflect.Value) (reflect.Value, error)
+	preserveNumericType          bool
+	roundTripSafety              bool
+	requiredFields               []string
+	acronyms                     map[string]bool
+	keyAcronyms                  map[string]bool
+	tagName                      string
+	matchTag                     string
+	normalizeKey                 func(string) string
+	normalizeKeys                func(string) string
+	fieldAliases                 map[string][]string
+	unknownKeys                  *map[string]interface{}
+	errorOnInvalidValue          bool
+	ignoreUnexportedTypes        bool
+	debugDumpOnError             bool
+	maxErrorValueLen             int
+	panicOnTypeMismatch          bool
+	errorFormatter               func(*TypeMismatchError) string
+	trace                        *[]TraceEntry
+	copyUnsafePointers           bool
+	deterministicOrder           bool
+	disableCycleDetection        bool
+	schemaValidation             bool
+	defaults                     map[string]interface{}
+	skipEmptyStructs             bool
+	skipEqualSubtrees            bool
+	mapSliceMergeKey             string
+	sliceMergeKeys               []string
+	sliceDeepMergeKey            string
+	sliceMergeByKeyTombstone     string
+	strictSliceLength            bool
+	elementConflictResolver      func(dst, src reflect.Value) (reflect.Value, error)
+	strictInterfaces             bool
+	canonicalizePointers         bool
+	interfaceFactories           map[reflect.Type]func() interface{}
+	discriminator                string
+	discriminatorRegistry        map[string]func() interface{}
+	maxFields                    int
+	maxVisitedEntries            int
+	maxBytes                     int64
+	parallelism                  int
+	aggregateTransformerErrors   bool
+	accumulateErrors             bool
 	debug                        bool
+	profile                      Profile
+	profileConflict              bool
+
+	// fieldCount is the number of fields and keys processed so far,
+	// checked against maxFields.
+	fieldCount int
+	// copiedBytes estimates the bytes allocated so far by copies and
+	// appends, checked against maxBytes.
+	copiedBytes int64
+	// path locates the value being merged, see currentPath.
+	path []pathElement
+	// errors collects the errors that don't abort the merge.
+	errors []error
+
+	// mapQueue holds the nested maps pending to be merged when
+	// iterativeMapMerge is enabled. It is only set during a merge.
+	mapQueue *[]mapMergeWork
+}
+
+// mapMergeWork is a pair of nested maps deferred by the iterative map merge.
+// As the queue is processed depth first, the path of the parent map is still
+// the current one up to level when a work item is taken, so only the key of the
+// nested map needs to be kept.
+type mapMergeWork struct {
+	dst, src reflect.Value
+	depth    int
+	level    int
+	key      pathElement
+	keyed    bool
+}
+
+type typeHook struct {
+	before, after func(dst, src reflect.Value) error
 }
 
 type Transformers interface {
@@ -55,8 +197,11 @@ type Transformers interface {
 // Traverses recursively both values, assigning src's fields values to dst.
 // The map argument tracks comparisons that have already been seen, which allows
 // short circuiting on recursive types.
-func deepMerge(dst, src reflect.Value, visited map[uintptr]*visit, depth int, config *Config) (err error) {
+func deepMerge(dst, src reflect.Value, visited map[visit]bool, depth int, config *Config) (err error) {
 	overwrite := config.Overwrite
+	if dst.IsValid() {
+		overwrite = mustOverwrite(dst.Type(), config)
+	}
 	typeCheck := config.TypeCheck
 	overwriteWithEmptySrc := config.overwriteWithEmptyValue
 	overwriteSliceWithEmptySrc := config.overwriteSliceWithEmptyValue
@@ -65,41 +210,186 @@ func deepMerge(dst, src reflect.Value, visited map[uintptr]*visit, depth int, co
 	if !src.IsValid() {
 		return
 	}
-	if dst.CanAddr() {
-		addr := dst.UnsafeAddr()
-		h := 17 * addr
-		seen := visited[h]
-		typ := dst.Type()
-		for p := seen; p != nil; p = p.next {
-			if p.ptr == addr && p.typ == typ {
-				return nil
+	if config.ctx != nil {
+		if err = config.ctx.Err(); err != nil {
+			return
+		}
+	}
+	if config.skipIf != nil && config.skipIf(currentPath(config), dst, src) {
+		traceDecision(config, TraceKeep, dst, dst)
+		return
+	}
+	if config.clearMarkers != nil && isClearMarker(src, config) && dst.CanSet() && src.Type() == dst.Type() {
+		traceDecision(config, TraceSet, dst, reflect.Zero(dst.Type()))
+		dst.Set(reflect.Zero(dst.Type()))
+		return
+	}
+	if config.debugDumpOnError {
+		defer dumpOnError(&err, dst, src, config)
+	}
+	if config.iterativeMapMerge && config.mapQueue == nil && dst.Kind() == reflect.Map {
+		return deepMergeMapsIteratively(dst, src, visited, depth, config)
+	}
+	if dst.CanAddr() && !config.disableCycleDetection {
+		// Remember, remember... in a single lookup, as every addressable
+		// value goes through here.
+		n := len(visited)
+		if visited[visit{dst.UnsafeAddr(), dst.Type()}] = true; len(visited) == n {
+			return nil
+		}
+		if config.maxVisitedEntries > 0 && len(visited) > config.maxVisitedEntries {
+			return ErrTooManyNodes
+		}
+	}
+
+	if config.skipEqualSubtrees && dst.Kind() == reflect.Struct && src.Type() == dst.Type() && dst.CanInterface() && src.CanInterface() &&
+		reflect.DeepEqual(dst.Interface(), src.Interface()) {
+		traceDecision(config, TraceKeep, dst, dst)
+		return
+	}
+
+	if config.typeHooks != nil && dst.IsValid() {
+		if hook, ok := config.typeHooks[dst.Type()]; ok {
+			if hook.before != nil {
+				if err = hook.before(dst, src); err != nil {
+					return
+				}
+			}
+			if hook.after != nil {
+				defer func() {
+					if err == nil {
+						err = hook.after(dst, src)
+					}
+				}()
 			}
 		}
-		// Remember, remember...
-		visited[h] = &visit{addr, typ, seen}
 	}
 
-	if config.Transformers != nil && !isEmptyValue(dst) {
-		if fn := config.Transformers.Transformer(dst.Type()); fn != nil {
-			err = fn(dst, src)
+	if hasTransformers(config) && !isEmptyValue(dst) {
+		if fn := transformerFor(dst.Type(), config); fn != nil {
+			if err = fn(dst, src); err != nil && config.aggregateTransformerErrors {
+				config.errors = append(config.errors, &TransformerError{Path: currentPath(config), Err: err})
+				err = nil
+			}
+			return
+		}
+	}
+
+	if dst.IsValid() && traitsOf(dst.Type()).orderedMap {
+		var merged bool
+		if merged, err = deepMergeOrderedMap(dst, src, visited, depth, config); merged || err != nil {
+			return
+		}
+	}
+
+	if config.shouldOverwrite != nil && dst.CanSet() && isLeafValue(dst) && src.Type().AssignableTo(dst.Type()) {
+		overwrite = config.shouldOverwrite(currentPath(config), dst, src)
+		if overwrite && isBigNumberType(dst.Type()) && mergeBigNumber(dst, src, true, true, config) {
+			// Copied, so they don't share storage with src.
+			return
+		}
+		if overwrite {
+			if src, err = srcCopy(src, config); err != nil {
+				return
+			}
+			traceDecision(config, TraceSet, dst, src)
+			dst.Set(src)
+		} else {
+			traceDecision(config, TraceKeep, dst, dst)
+		}
+		return
+	}
+
+	if dst.IsValid() && isBigNumberType(dst.Type()) && mergeBigNumber(dst, src, overwrite, overwriteWithEmptySrc, config) {
+		return
+	}
+
+	if dst.IsValid() && isErrorValue(dst) && mergeError(dst, src, overwrite, overwriteWithEmptySrc, config) {
+		return
+	}
+
+	if config.writerFields {
+		var written bool
+		if written, err = writeInto(dst, src); written || err != nil {
 			return
 		}
 	}
 
+	if config.skipZeroTimeOverwrite && dst.IsValid() && isTimeType(dst.Type()) && isZeroTime(src) {
+		return
+	}
+
 	switch dst.Kind() {
 	case reflect.Struct:
+		if value, ok := optionalValue(src); ok {
+			if value.IsValid() && dst.CanSet() {
+				dst.Set(src)
+			}
+			break
+		}
+		if config.skipEmptyStructs && isEmptyStruct(src) {
+			break
+		}
+		if config.matchTag != "" && src.Kind() == reflect.Struct && src.Type() != dst.Type() {
+			err = deepMergeByTag(dst, src, visited, depth, config)
+			break
+		}
+		if src.Type() != dst.Type() {
+			return typeMismatch(config, ErrDifferentArgumentsTypes, dst.Type(), src.Type())
+		}
 		if hasMergeableFields(dst) {
-			for i, n := 0, dst.NumField(); i < n; i++ {
-				if err = deepMerge(dst.Field(i), src.Field(i), visited, depth+1, config); err != nil {
+			if config.parallelism > 1 && depth == 0 {
+				err = deepMergeFieldsConcurrently(dst, src, depth, config)
+				break
+			}
+			fields := structFieldsOf(dst.Type())
+			for i := range fields {
+				if err = countField(config); err != nil {
+					return
+				}
+				if config.ignoreUnexportedTypes && isUnexportedType(fields[i].Type) {
+					continue
+				}
+				if config.mergeOnlyIfNil && isSetReference(dst.Field(i)) {
+					continue
+				}
+				if fields[i].readOnly && protectField(dst, i, config) {
+					continue
+				}
+				if config.onlyEmbedded != nil && depth == 0 && !isOnlyEmbedded(fields[i].StructField, config) {
+					continue
+				}
+				pushField(config, fields[i].Name)
+				err = deepMergeField(dst, src, i, visited, depth, config)
+				popPath(config)
+				if err != nil {
 					return
 				}
 			}
 		} else {
 			if dst.CanSet() && (isReflectNil(dst) || overwrite) && (!isEmptyValue(src) || overwriteWithEmptySrc) {
+				if src, err = srcCopy(src, config); err != nil {
+					return
+				}
+				traceDecision(config, TraceSet, dst, src)
 				dst.Set(src)
+			} else {
+				traceDecision(config, TraceKeep, dst, dst)
 			}
 		}
 	case reflect.Map:
+		if src.Kind() == reflect.Map && src.IsNil() {
+			if overwriteWithEmptySrc && dst.CanSet() && !dst.IsNil() {
+				dst.Set(reflect.Zero(dst.Type()))
+			}
+			break
+		}
+		if src.Kind() == reflect.Map && !mapElementsAssignable(dst.Type(), src.Type()) {
+			return typeMismatch(config, ErrDifferentArgumentsTypes, dst.Type(), src.Type())
+		}
+		if src, err = normalizeMapKeys(src, config); err != nil {
+			return
+		}
 		if dst.IsNil() && !src.IsNil() {
 			if dst.CanSet() {
 				dst.Set(reflect.MakeMap(dst.Type()))
@@ -110,13 +400,20 @@ func deepMerge(dst, src reflect.Value, visited map[uintptr]*visit, depth int, co
 		}
 
 		if src.Kind() != reflect.Map {
-			if overwrite {
+			// Unaddressable maps, unwrapped from the elements of another
+			// map, are replaced by the caller.
+			if overwrite && dst.CanSet() && src.Type().AssignableTo(dst.Type()) {
 				dst.Set(src)
 			}
 			return
 		}
 
-		for _, key := range src.MapKeys() {
+		pushKey(config, reflect.Value{})
+		for _, key := range mapKeys(src, config) {
+			config.path[len(config.path)-1] = pathElement{key: key}
+			if err = countField(config); err != nil {
+				return
+			}
 			srcElement := src.MapIndex(key)
 			if !srcElement.IsValid() {
 				continue
@@ -124,8 +421,14 @@ func deepMerge(dst, src reflect.Value, visited map[uintptr]*visit, depth int, co
 			dstElement := dst.MapIndex(key)
 			switch srcElement.Kind() {
 			case reflect.Chan, reflect.Func, reflect.Map, reflect.Interface, reflect.Slice:
-				if srcElement.IsNil() {
-					if overwrite {
+				if srcElement.IsNil() || isTypedNilCollection(srcElement) {
+					// A nil func is no callback, so it only replaces another
+					// one when empty values are meant to.
+					if overwrite && (srcElement.Kind() != reflect.Func || overwriteWithEmptySrc) {
+						if !srcElement.Type().AssignableTo(dst.Type().Elem()) {
+							// A nil interface into a map of pointers.
+							srcElement = reflect.Zero(dst.Type().Elem())
+						}
 						dst.SetMapIndex(key, srcElement)
 					}
 					continue
@@ -135,8 +438,56 @@ func deepMerge(dst, src reflect.Value, visited map[uintptr]*visit, depth int, co
 				if !srcElement.CanInterface() {
 					continue
 				}
+				if config.skipEmptyStructs {
+					if v := reflect.ValueOf(srcElement.Interface()); v.Kind() == reflect.Struct && isEmptyStruct(v) {
+						continue
+					}
+				}
+				if config.preserveNumericType && dstElement.IsValid() {
+					srcElement = preserveNumericType(dstElement, srcElement)
+				}
+				var transformed, boxed bool
+				if transformed, err = transformMapElement(dst, key, srcElement, config); err != nil {
+					return
+				} else if transformed {
+					continue
+				}
+				var merged bool
+				if merged, err = mergeMapElementMergeable(dst, key, dstElement, srcElement, config); err != nil {
+					return
+				} else if merged {
+					continue
+				}
+				if boxed, err = deepMergeBoxedMapElement(dst, key, srcElement, visited, depth, config); err != nil {
+					return
+				} else if boxed {
+					continue
+				}
+				if elemType := dst.Type().Elem(); !srcElement.Type().AssignableTo(elemType) {
+					// Only maps of pointers get elements of other types,
+					// boxed above.
+					if srcElement = unwrapInterface(srcElement); !srcElement.Type().AssignableTo(elemType) {
+						if err = typeMismatch(config, ErrDifferentArgumentsTypes, elemType, srcElement.Type()); err != nil {
+							return
+						}
+						continue
+					}
+				}
 				switch reflect.TypeOf(srcElement.Interface()).Kind() {
+				case reflect.Func:
+					// Funcs can't be deep merged, so they are replaced below.
 				case reflect.Struct:
+					if held := unwrapInterface(dstElement); held.IsValid() && held.Type() == reflect.TypeOf(srcElement.Interface()) {
+						// Map values aren't addressable, so merge into a
+						// copy and store it back.
+						copied := reflect.New(held.Type()).Elem()
+						copied.Set(held)
+						if err = deepMerge(copied, reflect.ValueOf(srcElement.Interface()), visited, depth+1, config); err != nil {
+							return
+						}
+						dst.SetMapIndex(key, copied)
+						continue
+					}
 					fallthrough
 				case reflect.Ptr:
 					fallthrough
@@ -149,7 +500,19 @@ func deepMerge(dst, src reflect.Value, visited map[uintptr]*visit, depth int, co
 							dstMapElm = reflect.ValueOf(dstMapElm.Interface())
 						}
 					}
-					if err = deepMerge(dstMapElm, srcMapElm, visited, depth+1, config); err != nil {
+					if dstMapElm.IsValid() && dstMapElm.Kind() != srcMapElm.Kind() {
+						// Values of different kinds can't be merged, so
+						// they are replaced below when empty.
+						if overwrite && !isEmptyValue(dstMapElm) && !isEmptyValue(srcMapElm) {
+							if err = typeMismatch(config, ErrDifferentArgumentsTypes, dstMapElm.Type(), srcMapElm.Type()); err != nil {
+								return
+							}
+						}
+						break
+					}
+					if config.mapQueue != nil && srcMapElm.Kind() == reflect.Map && dstMapElm.Kind() == reflect.Map {
+						*config.mapQueue = append(*config.mapQueue, mapMergeWork{dstMapElm, srcMapElm, depth + 1, len(config.path) - 1, config.path[len(config.path)-1], true})
+					} else if err = deepMerge(dstMapElm, srcMapElm, visited, depth+1, config); err != nil {
 						return
 					}
 				case reflect.Slice:
@@ -158,20 +521,41 @@ func deepMerge(dst, src reflect.Value, visited map[uintptr]*visit, depth int, co
 					var dstSlice reflect.Value
 					if !dstElement.IsValid() || dstElement.IsNil() {
 						dstSlice = reflect.MakeSlice(srcSlice.Type(), 0, srcSlice.Len())
-					} else {
-						dstSlice = reflect.ValueOf(dstElement.Interface())
+					} else if dstSlice = reflect.ValueOf(dstElement.Interface()); dstSlice.Kind() != reflect.Slice {
+						if overwrite && !isEmptyValue(dstSlice) && !isEmptyValue(srcSlice) {
+							if err = typeMismatch(config, ErrDifferentArgumentsTypes, dstSlice.Type(), srcSlice.Type()); err != nil {
+								return
+							}
+						}
+						break
 					}
 
-					if (!isEmptyValue(src) || overwriteWithEmptySrc || overwriteSliceWithEmptySrc) && (overwrite || isEmptyValue(dst)) && !config.AppendSlice && !sliceDeepCopy {
+					if keys, ok := mergeKeys(dstSlice, srcSlice, config); ok {
+						if dstSlice, err = deepMergeSliceByKeys(dstSlice, srcSlice, keys, visited, depth+1, config); err != nil {
+							return
+						}
+					} else if (!isEmptyValue(src) || overwriteWithEmptySrc || overwriteSliceWithEmptySrc) && (overwrite || isEmptyValue(dst)) && !config.AppendSlice && !sliceDeepCopy {
 						if typeCheck && srcSlice.Type() != dstSlice.Type() {
-							return fmt.Errorf("cannot override two slices with different type (%s, %s)", srcSlice.Type(), dstSlice.Type())
+							if err = typeMismatch(config, fmt.Errorf("cannot override two slices with different type (%s, %s)", srcSlice.Type(), dstSlice.Type()), dstSlice.Type(), srcSlice.Type()); err != nil {
+								return
+							}
+							continue
 						}
 						dstSlice = srcSlice
+						if config.mapDeepCopy {
+							if err = chargeCopy(config, srcSlice); err != nil {
+								return
+							}
+							dstSlice = deepCopy(srcSlice, make(map[copyKey]reflect.Value))
+						}
 					} else if config.AppendSlice {
-						if srcSlice.Type() != dstSlice.Type() {
-							return fmt.Errorf("cannot append two slices with different type (%s, %s)", srcSlice.Type(), dstSlice.Type())
+						if dstSlice, err = appendSlice(dstSlice, srcSlice, config); err != nil {
+							return
 						}
-						dstSlice = reflect.AppendSlice(dstSlice, srcSlice)
+					} else if sliceDeepCopy && config.strictSliceLength && srcSlice.Len() > 0 && dstSlice.Len() != srcSlice.Len() {
+						return sliceLengthMismatch(dstSlice, srcSlice, config)
+					} else if sliceDeepCopy && config.emptyStringElementsAbsent && dstSlice.Type().Elem().Kind() == reflect.String {
+						mergeStringsByIndex(dstSlice, srcSlice, overwrite, config)
 					} else if sliceDeepCopy {
 						i := 0
 						for ; i < srcSlice.Len() && i < dstSlice.Len(); i++ {
@@ -181,11 +565,19 @@ func deepMerge(dst, src reflect.Value, visited map[uintptr]*visit, depth int, co
 							if srcElement.CanInterface() {
 								srcElement = reflect.ValueOf(srcElement.Interface())
 							}
-							if dstElement.CanInterface() {
+							if dstElement.Kind() == reflect.Interface && dstElement.CanInterface() {
+								// Other elements are kept addressable, so
+								// structs are merged in place.
 								dstElement = reflect.ValueOf(dstElement.Interface())
 							}
+							if dstElement.IsValid() && srcElement.IsValid() && dstElement.Type() != srcElement.Type() {
+								continue
+							}
 
-							if err = deepMerge(dstElement, srcElement, visited, depth+1, config); err != nil {
+							pushIndex(config, i)
+							err = deepMerge(dstElement, srcElement, visited, depth+1, config)
+							popPath(config)
+							if err != nil {
 								return
 							}
 						}
@@ -198,24 +590,61 @@ func deepMerge(dst, src reflect.Value, visited map[uintptr]*visit, depth int, co
 				continue
 			}
 
-			if srcElement.IsValid() && ((srcElement.Kind() != reflect.Ptr && overwrite) || !dstElement.IsValid() || isEmptyValue(dstElement)) {
+			mustSet := srcElement.IsValid() && ((srcElement.Kind() != reflect.Ptr && mustOverwrite(reflect.TypeOf(srcElement.Interface()), config)) || !dstElement.IsValid() || isEmptyValue(dstElement))
+			if config.shouldOverwrite != nil && srcElement.IsValid() && isLeafValue(unwrapInterface(dstElement)) {
+				mustSet = config.shouldOverwrite(currentPath(config), dstElement, srcElement)
+			}
+			if mustSet {
 				if dst.IsNil() {
 					dst.Set(reflect.MakeMap(dst.Type()))
 				}
+				if config.mapDeepCopy {
+					if err = chargeCopy(config, srcElement); err != nil {
+						return
+					}
+					srcElement = deepCopy(srcElement, make(map[copyKey]reflect.Value))
+				}
+				traceDecision(config, TraceSet, dstElement, srcElement)
 				dst.SetMapIndex(key, srcElement)
+			} else {
+				traceDecision(config, TraceKeep, dstElement, dstElement)
 			}
 		}
+		popPath(config)
 	case reflect.Slice:
 		if !dst.CanSet() {
 			break
 		}
-		if (!isEmptyValue(src) || overwriteWithEmptySrc || overwriteSliceWithEmptySrc) && (overwrite || isEmptyValue(dst)) && !config.AppendSlice && !sliceDeepCopy {
+		if src.Kind() != reflect.Slice {
+			return typeMismatch(config, ErrDifferentArgumentsTypes, dst.Type(), src.Type())
+		}
+		if keys, ok := mergeKeys(dst, src, config); ok {
+			var merged reflect.Value
+			if merged, err = deepMergeSliceByKeys(dst, src, keys, visited, depth+1, config); err != nil {
+				return
+			}
+			traceDecision(config, TraceSet, dst, merged)
+			dst.Set(merged)
+		} else if (!isEmptyValue(src) || overwriteWithEmptySrc || (overwriteSliceWithEmptySrc && !src.IsNil())) && (overwrite || isEmptyValue(dst)) && !config.AppendSlice && !sliceDeepCopy {
+			if !src.Type().AssignableTo(dst.Type()) {
+				return typeMismatch(config, ErrDifferentArgumentsTypes, dst.Type(), src.Type())
+			}
+			if src, err = srcCopy(src, config); err != nil {
+				return
+			}
+			traceDecision(config, TraceSet, dst, src)
 			dst.Set(src)
 		} else if config.AppendSlice {
-			if src.Type() != dst.Type() {
-				return fmt.Errorf("cannot append two slice with different type (%s, %s)", src.Type(), dst.Type())
+			var appended reflect.Value
+			if appended, err = appendSlice(dst, src, config); err != nil {
+				return
 			}
-			dst.Set(reflect.AppendSlice(dst, src))
+			traceDecision(config, TraceAppend, dst, appended)
+			dst.Set(appended)
+		} else if sliceDeepCopy && config.strictSliceLength && src.Len() > 0 && dst.Len() != src.Len() {
+			return sliceLengthMismatch(dst, src, config)
+		} else if sliceDeepCopy && config.emptyStringElementsAbsent && dst.Type().Elem().Kind() == reflect.String {
+			mergeStringsByIndex(dst, src, overwrite, config)
 		} else if sliceDeepCopy {
 			for i := 0; i < src.Len() && i < dst.Len(); i++ {
 				srcElement := src.Index(i)
@@ -223,28 +652,60 @@ func deepMerge(dst, src reflect.Value, visited map[uintptr]*visit, depth int, co
 				if srcElement.CanInterface() {
 					srcElement = reflect.ValueOf(srcElement.Interface())
 				}
-				if dstElement.CanInterface() {
+				if dstElement.Kind() == reflect.Interface && dstElement.CanInterface() {
 					dstElement = reflect.ValueOf(dstElement.Interface())
 				}
+				if dstElement.IsValid() && srcElement.IsValid() && dstElement.Type() != srcElement.Type() {
+					// Mixed elements of a slice of interfaces can't be merged.
+					continue
+				}
 
-				if err = deepMerge(dstElement, srcElement, visited, depth+1, config); err != nil {
+				pushIndex(config, i)
+				var resolved bool
+				if resolved, err = resolveElementConflict(dst.Index(i), dstElement, srcElement, config); !resolved {
+					err = deepMerge(dstElement, srcElement, visited, depth+1, config)
+				}
+				popPath(config)
+				if err != nil {
 					return
 				}
 			}
+		} else {
+			traceDecision(config, TraceKeep, dst, dst)
 		}
 	case reflect.Ptr:
 		fallthrough
 	case reflect.Interface:
 		if isReflectNil(src) {
 			if overwriteWithEmptySrc && dst.CanSet() && src.Type().AssignableTo(dst.Type()) {
+				traceDecision(config, TraceSet, dst, src)
 				dst.Set(src)
+			} else {
+				traceDecision(config, TraceKeep, dst, dst)
 			}
 			break
 		}
 
+		if dst.Kind() == reflect.Interface && dst.IsNil() && dst.CanSet() {
+			if factory, ok := config.interfaceFactories[dst.Type()]; ok {
+				err = deepMergeIntoFactory(dst, src, factory, visited, depth, config)
+				break
+			}
+			if config.strictInterfaces {
+				return fmt.Errorf("%w: %s", ErrNilInterfaceDestination, dst.Type())
+			}
+		}
+
 		if src.Kind() != reflect.Interface {
 			if dst.IsNil() || (src.Kind() != reflect.Ptr && overwrite) {
+				if !src.Type().AssignableTo(dst.Type()) {
+					return typeMismatch(config, ErrDifferentArgumentsTypes, dst.Type(), src.Type())
+				}
 				if dst.CanSet() && (overwrite || isEmptyValue(dst)) {
+					if src, err = srcCopy(src, config); err != nil {
+						return
+					}
+					traceDecision(config, TraceSet, dst, src)
 					dst.Set(src)
 				}
 			} else if src.Kind() == reflect.Ptr {
@@ -256,46 +717,233 @@ func deepMerge(dst, src reflect.Value, visited map[uintptr]*visit, depth int, co
 					return
 				}
 			} else {
-				return ErrDifferentArgumentsTypes
+				return typeMismatch(config, ErrDifferentArgumentsTypes, dst.Elem().Type(), src.Type())
 			}
 			break
 		}
 
+		if !dst.IsNil() && dst.CanSet() {
+			if winner, ok := mostComplete(dst.Elem(), src.Elem(), config); ok {
+				traceDecision(config, TraceSet, dst, winner)
+				dst.Set(winner)
+				break
+			}
+		}
 		if dst.IsNil() || overwrite {
 			if dst.CanSet() && (overwrite || isEmptyValue(dst)) {
+				if src, err = srcCopy(src, config); err != nil {
+					return
+				}
+				traceDecision(config, TraceSet, dst, src)
 				dst.Set(src)
 			}
 			break
 		}
 
-		if dst.Elem().Kind() == src.Elem().Kind() {
-			if err = deepMerge(dst.Elem(), src.Elem(), visited, depth+1, config); err != nil {
+		if held := dst.Elem(); held.Type() == src.Elem().Type() {
+			if held.Kind() == reflect.Ptr || !dst.CanSet() {
+				err = deepMerge(held, src.Elem(), visited, depth+1, config)
+				break
+			}
+			// Values held by an interface aren't addressable, so merge
+			// into a copy and store it back.
+			copied := reflect.New(held.Type()).Elem()
+			copied.Set(held)
+			if err = deepMerge(copied, src.Elem(), visited, depth+1, config); err != nil {
 				return
 			}
+			dst.Set(copied)
 			break
 		}
+		if config.canonicalizePointers {
+			_, err = deepMergeCanonical(dst, dst.Elem(), src.Elem(), visited, depth, config)
+		}
+	case reflect.Uintptr, reflect.UnsafePointer:
+		// Addresses are rarely meant to be merged, so they are skipped
+		// unless WithCopyUnsafePointers is used.
+		if config.copyUnsafePointers && dst.CanSet() && (isEmptyValue(dst) || overwrite) && (!isEmptyValue(src) || overwriteWithEmptySrc) {
+			dst.Set(src)
+		}
 	default:
 		mustSet := (isEmptyValue(dst) || overwrite) && (!isEmptyValue(src) || overwriteWithEmptySrc)
 		if mustSet {
+			if src, err = srcCopy(src, config); err != nil {
+				return
+			}
+			traceDecision(config, TraceSet, dst, src)
 			if dst.CanSet() {
 				dst.Set(src)
 			} else {
 				dst = src
 			}
+		} else {
+			traceDecision(config, TraceKeep, dst, dst)
 		}
 	}
 
 	return
 }
 
+// mapElementsAssignable reports whether the keys and elements of src, a map
+// type, can be stored into dst, another one, boxing them when dst is a map of
+// pointers, see deepMergeBoxedMapElement.
+func mapElementsAssignable(dst, src reflect.Type) bool {
+	if !src.Key().AssignableTo(dst.Key()) {
+		return false
+	}
+	if src.Elem().AssignableTo(dst.Elem()) {
+		return true
+	}
+	return dst.Elem().Kind() == reflect.Ptr && (src.Elem().Kind() == reflect.Interface || src.Elem().AssignableTo(dst.Elem().Elem()))
+}
+
+// deepMergeBoxedMapElement handles src, a non-pointer value, when dst is a map of
+// pointers to its type: it is merged into the pointed value of dst at key, which
+// is allocated when missing. The same applies when dst is a map of interfaces
+// already holding a pointer to src type at key. It reports whether the element
+// was handled.
+func deepMergeBoxedMapElement(dst, key, src reflect.Value, visited map[visit]bool, depth int, config *Config) (bool, error) {
+	elemType := dst.Type().Elem()
+	if elemType.Kind() == reflect.Interface {
+		src = unwrapInterface(src)
+		held := unwrapInterface(dst.MapIndex(key))
+		if !src.IsValid() || src.Kind() == reflect.Ptr || !held.IsValid() || held.Kind() != reflect.Ptr || held.IsNil() || held.Type().Elem() != src.Type() {
+			return false, nil
+		}
+		return true, deepMerge(held.Elem(), src, visited, depth+1, config)
+	}
+	if elemType.Kind() != reflect.Ptr {
+		return false, nil
+	}
+	src = unwrapInterface(src)
+	if !src.IsValid() || src.Kind() == reflect.Ptr || !src.Type().AssignableTo(elemType.Elem()) {
+		return false, nil
+	}
+	if dstElement := dst.MapIndex(key); dstElement.IsValid() && !dstElement.IsNil() {
+		return true, deepMerge(dstElement.Elem(), src, visited, depth+1, config)
+	}
+	src, err := srcCopy(src, config)
+	if err != nil {
+		return true, err
+	}
+	boxed := reflect.New(elemType.Elem())
+	boxed.Elem().Set(src)
+	dst.SetMapIndex(key, boxed)
+	return true, nil
+}
+
+// deepMergeMapsIteratively merges dst and src like deepMerge does, but nested
+// maps are pushed to a heap-allocated queue instead of being merged recursively.
+func deepMergeMapsIteratively(dst, src reflect.Value, visited map[visit]bool, depth int, config *Config) (err error) {
+	queue := []mapMergeWork{{dst, src, depth, len(config.path), pathElement{}, false}}
+	config.mapQueue = &queue
+	path := config.path
+	defer func() {
+		config.mapQueue = nil
+		config.path = path
+	}()
+	for len(queue) > 0 {
+		work := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		config.path = config.path[:work.level]
+		if work.keyed {
+			config.path = append(config.path, work.key)
+		}
+		if err = deepMerge(work.dst, work.src, visited, work.depth, config); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// deepMergeIntoFactory sets dst, a nil interface, to a value built by factory
+// after deep merging src into it.
+func deepMergeIntoFactory(dst, src reflect.Value, factory func() interface{}, visited map[visit]bool, depth int, config *Config) error {
+	value := reflect.ValueOf(factory())
+	target, source := value, unwrapInterface(src)
+	if target.Kind() == reflect.Ptr {
+		target = target.Elem()
+	}
+	if source.Kind() == reflect.Ptr {
+		source = source.Elem()
+	}
+	if !target.CanSet() || target.Type() != source.Type() || !value.Type().AssignableTo(dst.Type()) {
+		return ErrDifferentArgumentsTypes
+	}
+	if err := deepMerge(target, source, visited, depth+1, config); err != nil {
+		return err
+	}
+	dst.Set(value)
+	return nil
+}
+
+// countField accounts for a processed field or map key, failing with
+// ErrTooManyFields once the limit set by WithMaxFields is exceeded.
+func countField(config *Config) error {
+	if config.maxFields <= 0 {
+		return nil
+	}
+	if config.fieldCount++; config.fieldCount > config.maxFields {
+		return ErrTooManyFields
+	}
+	return nil
+}
+
+// markVisited adds v to visited, failing with ErrTooManyNodes if it already
+// holds config.maxVisitedEntries values.
+func markVisited(visited map[visit]bool, v visit, config *Config) error {
+	if config.maxVisitedEntries > 0 && len(visited) >= config.maxVisitedEntries {
+		return ErrTooManyNodes
+	}
+	visited[v] = true
+	return nil
+}
+
 // Merge will fill any empty for value type attributes on the dst struct using corresponding
 // src attributes if they themselves are not empty. dst and src must be valid same-type structs
-// and dst must be a pointer to struct.
+// and dst must be a pointer to struct. dst may also be a pointer to a slice, merged
+// with src, a slice of the same type, following the slice options like WithAppendSlice.
 // It won't merge unexported (private) fields and will do recursively any exported field.
+// A nil slice or map in a src struct can't be told apart from an unset field, so it never
+// touches dst unless WithOverwriteWithEmptyValue is used. Inside maps, a key holding a typed
+// nil slice or map is an explicit value: it clears dst's one under WithOverride, while a
+// missing key leaves it untouched. uintptr and unsafe.Pointer fields are skipped unless
+// WithCopyUnsafePointers is used. big.Float, big.Rat and big.Int values are copied as a
+// whole, so dst never shares their storage with src. Fields tagged `mergo:"readonly"` are
+// never modified, whatever the options.
 func Merge(dst, src interface{}, opts ...func(*Config)) error {
 	return merge(dst, src, opts...)
 }
 
+// MergeAndReturn does the same as Merge and returns dst itself, not a copy of
+// it, so calls can be chained.
+func MergeAndReturn(dst, src interface{}, opts ...func(*Config)) (interface{}, error) {
+	return dst, merge(dst, src, opts...)
+}
+
+// MergeContext does the same as Merge, but stops with the error of ctx once it
+// is done. ctx is given to the transformers implementing ContextTransformers,
+// and to scoped ones through their HookContext, so they can stop too.
+func MergeContext(ctx context.Context, dst, src interface{}, opts ...func(*Config)) error {
+	return merge(dst, src, append(opts, func(config *Config) {
+		config.ctx = ctx
+	})...)
+}
+
+// MergeVia does the same as Merge into the pointer returned by accessor for
+// dst, like the state exposed by a Mutable() *State method, so encapsulated
+// types can be merged without direct access to their fields.
+func MergeVia(dst interface{}, accessor func(interface{}) interface{}, src interface{}, opts ...func(*Config)) error {
+	if dst == nil || accessor == nil {
+		return ErrNilArguments
+	}
+	target := accessor(dst)
+	if target == nil || isReflectNil(reflect.ValueOf(target)) {
+		return ErrNilArguments
+	}
+	return merge(target, src, opts...)
+}
+
 // MergeWithOverwrite will do the same as Merge except that non-empty dst attributes will be overridden by
 // non-empty src attribute values.
 // Deprecated: use Merge(…) with WithOverride
@@ -303,7 +951,65 @@ func MergeWithOverwrite(dst, src interface{}, opts ...func(*Config)) error {
 	return merge(dst, src, append(opts, WithOverride)...)
 }
 
+// MergeTo merges b into a deep copy of a and writes the result into out, which
+// must be a pointer to a's type (or to the type a points to). Neither a nor b
+// are modified, and out doesn't share any storage reachable through exported
+// fields with them. Unexported fields are copied as is, so pointers, slices and
+// maps they hold are still shared.
+func MergeTo(out, a, b interface{}, opts ...func(*Config)) error {
+	if out == nil || a == nil || b == nil {
+		return ErrNilArguments
+	}
+	vOut := reflect.ValueOf(out)
+	if vOut.Kind() != reflect.Ptr || vOut.IsNil() {
+		return ErrNonPointerAgument
+	}
+	base := reflect.ValueOf(a)
+	if base.Kind() == reflect.Ptr {
+		if base.IsNil() {
+			return ErrNilArguments
+		}
+		base = base.Elem()
+	}
+	if base.Type() != vOut.Elem().Type() {
+		return ErrDifferentArgumentsTypes
+	}
+	config := &Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if err := chargeCopy(config, base); err != nil {
+		return err
+	}
+	if err := chargeCopy(config, reflect.ValueOf(b)); err != nil {
+		return err
+	}
+	vOut.Elem().Set(deepCopy(base, make(map[copyKey]reflect.Value)))
+	return mergeWithConfig(out, clone(b), config)
+}
+
+// MergeAll merges every src into dst, in order, so later sources fill what
+// earlier ones left empty. It stops at the first error.
+func MergeAll(dst interface{}, srcs ...interface{}) error {
+	return MergeAllWithOptions(dst, srcs)
+}
+
+// MergeAllWithOptions does the same as MergeAll, merging every src with opts.
+// Each merge starts from a fresh Config, so no state leaks between sources.
+func MergeAllWithOptions(dst interface{}, srcs []interface{}, opts ...func(*Config)) error {
+	for _, src := range srcs {
+		if err := merge(dst, src, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // WithTransformers adds transformers to merge, allowing to customize the merging of some types.
+// Map values are matched by their concrete type and the transformer gets a
+// settable copy of the current value (or a zero one) that is stored back.
+// They take precedence over those registered with WithTypeTransformer, see
+// WithKindTransformer for the full order.
 func WithTransformers(transformers Transformers) func(*Config) {
 	return func(config *Config) {
 		config.Transformers = transformers
@@ -315,13 +1021,145 @@ func WithOverride(config *Config) {
 	config.Overwrite = true
 }
 
+// WithOverrideTypes will make merge override only the fields and map values of
+// the given types, like time.Time, while the rest are only filled when empty,
+// whether WithOverride is used or not.
+func WithOverrideTypes(types ...reflect.Type) func(*Config) {
+	set := make(map[reflect.Type]bool, len(types))
+	for _, typ := range types {
+		set[typ] = true
+	}
+	return func(config *Config) {
+		config.overrideTypes = set
+	}
+}
+
+// WithShouldOverwrite will make merge call fn to decide whether the src value
+// at path overwrites the dst one, even when empty, instead of the usual rules.
+// It is called for values merged as a whole, like numbers, strings, slices and
+// structs without exported fields, while the rest are merged through their
+// fields, keys or pointed values.
+func WithShouldOverwrite(fn func(path string, dst, src reflect.Value) bool) func(*Config) {
+	return func(config *Config) {
+		config.shouldOverwrite = fn
+	}
+}
+
+// WithMatchByTag will make merge accept a src struct of a different type than
+// dst, pairing their fields by the value of their tagName tag, like "json",
+// instead of their Go names. Tag options after a comma are ignored, and fields
+// without a counterpart are skipped. Nested structs are paired the same way,
+// and a pointer paired with a value of its type is merged through what it
+// points to. Untagged embedded structs are paired with the ones of the same
+// type embedded in dst.
+func WithMatchByTag(tagName string) func(*Config) {
+	return func(config *Config) {
+		config.matchTag = tagName
+	}
+}
+
+// WithOnlyEmbedded will make merge only merge the structs embedded in dst whose
+// type has one of the given names, like Metadata or pkg.Metadata, skipping the
+// rest of its fields, so only some aspects of a composed struct are updated.
+func WithOnlyEmbedded(typeNames ...string) func(*Config) {
+	return func(config *Config) {
+		config.onlyEmbedded = make(map[string]bool, len(typeNames))
+		for _, name := range typeNames {
+			config.onlyEmbedded[name] = true
+		}
+	}
+}
+
+// isOnlyEmbedded reports whether field embeds one of the types given by
+// WithOnlyEmbedded.
+func isOnlyEmbedded(field reflect.StructField, config *Config) bool {
+	if !field.Anonymous {
+		return false
+	}
+	typ := field.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return config.onlyEmbedded[typ.Name()] || config.onlyEmbedded[typ.String()]
+}
+
+// WithMergeStrategyTag will make merge honor the `mergo:"slice=strategy"` tag
+// of slice fields, so a struct can mix strategies: "append" appends src to dst
+// like WithAppendSlice, "replace" sets dst to src when not empty, even without
+// WithOverride, and "bykey:Name" merges elements holding the same Name like
+// WithSliceMergeByKeys.
+func WithMergeStrategyTag(config *Config) {
+	config.mergeStrategyTag = true
+}
+
+// WithNamedTransforms will make merge pass the src value of the fields tagged
+// `mergo:"transform=name"` through the transform registered under name before
+// merging it, like an uppercase one, so transforms are declared on the struct.
+// Transforms must return a value of the type they are given.
+func WithNamedTransforms(transforms map[string]func(reflect.Value) reflect.Value) func(*Config) {
+	return func(config *Config) {
+		config.namedTransforms = transforms
+	}
+}
+
+// WithClearMarker will make merge reset a dst value to its zero value when the
+// src one is deeply equal to marker, of the same type, instead of setting it,
+// so patches can clear fields without pointers. One marker can be registered
+// per type, like a string and a []string one.
+func WithClearMarker(marker interface{}) func(*Config) {
+	return func(config *Config) {
+		if config.clearMarkers == nil {
+			config.clearMarkers = make(map[reflect.Type]interface{})
+		}
+		config.clearMarkers[reflect.TypeOf(marker)] = marker
+	}
+}
+
+func isClearMarker(v reflect.Value, config *Config) bool {
+	marker, ok := config.clearMarkers[v.Type()]
+	return ok && v.CanInterface() && reflect.DeepEqual(v.Interface(), marker)
+}
+
+// WithSkipIf will make merge and Map leave untouched the dst values for which
+// skip returns true, given their path like Spec.Listeners[2].Port, without
+// looking into them, so whole subtrees can be skipped at runtime.
+func WithSkipIf(skip func(path string, dst, src reflect.Value) bool) func(*Config) {
+	return func(config *Config) {
+		config.skipIf = skip
+	}
+}
+
+// WithFinalizer will make merge call finalize with dst once the whole merge
+// succeeded, to recompute derived state like an index of merged slices. Its
+// error is returned by merge, and with MergeAtomic nothing is stored then.
+func WithFinalizer(finalize func(dst interface{}) error) func(*Config) {
+	return func(config *Config) {
+		config.finalizer = finalize
+	}
+}
+
+// WithTypeHook will make merge call before and after, either of them being
+// optional, around the merge of the dst values of type typ, like to recompute a
+// field derived from the merged ones. Unlike a transformer, the value is still
+// merged as usual, and an error returned by a hook aborts the merge.
+func WithTypeHook(typ reflect.Type, before, after func(dst, src reflect.Value) error) func(*Config) {
+	return func(config *Config) {
+		if config.typeHooks == nil {
+			config.typeHooks = make(map[reflect.Type]typeHook)
+		}
+		config.typeHooks[typ] = typeHook{before, after}
+	}
+}
+
 // WithOverwriteWithEmptyValue will make merge override non empty dst attributes with empty src attributes values.
+// Nil slices and maps in src clear their dst counterparts.
 func WithOverwriteWithEmptyValue(config *Config) {
 	config.Overwrite = true
 	config.overwriteWithEmptyValue = true
 }
 
 // WithOverrideEmptySlice will make merge override empty dst slice with empty src slice.
+// Only non-nil empty slices do: a nil slice in src is considered unset.
 func WithOverrideEmptySlice(config *Config) {
 	config.overwriteSliceWithEmptyValue = true
 }
@@ -331,6 +1169,15 @@ func WithAppendSlice(config *Config) {
 	config.AppendSlice = true
 }
 
+// WithOnAppend will make WithAppendSlice call fn with every element appended
+// to a dst slice, in order, along with its path like Hosts[2], so layered
+// merges can be audited.
+func WithOnAppend(fn func(path string, appended reflect.Value)) func(*Config) {
+	return func(config *Config) {
+		config.onAppend = fn
+	}
+}
+
 // WithTypeCheck will make merge check types while overwriting it (must be used with WithOverride).
 func WithTypeCheck(config *Config) {
 	config.TypeCheck = true
@@ -342,7 +1189,340 @@ func WithSliceDeepCopy(config *Config) {
 	config.Overwrite = true
 }
 
+// WithStrictSliceLength will make WithSliceDeepCopy fail with
+// ErrSliceLengthMismatch when a non-empty src slice has a different length
+// than the dst one, instead of merging only the elements found in both, to
+// catch misaligned positional data.
+func WithStrictSliceLength(config *Config) {
+	config.strictSliceLength = true
+}
+
+// WithTreatEmptyStringSliceElementsAsAbsent will make WithSliceDeepCopy merge
+// string slices by index, keeping the dst element wherever the src one is
+// empty, so a sparse src list with "" placeholders only fills some positions.
+func WithTreatEmptyStringSliceElementsAsAbsent(config *Config) {
+	config.emptyStringElementsAbsent = true
+}
+
+// WithMapDeepCopy will make merge store deep copies of the src values it sets
+// into dst maps, so nested maps, slices and pointers aren't shared with src
+// and later changes to one of them don't show up in the other.
+func WithMapDeepCopy(config *Config) {
+	config.mapDeepCopy = true
+}
+
+// WithDeepCopy will make merge set deep copies of the src pointers, maps,
+// slices and interfaces it sets anywhere into dst, not only into maps like
+// WithMapDeepCopy, so dst shares no storage with src.
+func WithDeepCopy(config *Config) {
+	config.deepCopy = true
+	config.mapDeepCopy = true
+}
+
+// WithMergeOnlyIfNil will make merge leave alone the slice, map and pointer
+// fields of dst that aren't nil, even if they are empty, taking them as
+// explicitly initialized. Only nil ones, never set, are merged from src.
+func WithMergeOnlyIfNil(config *Config) {
+	config.mergeOnlyIfNil = true
+}
+
+// WithSkipZeroTimeOverwrite will make merge never overwrite time.Time and
+// *time.Time dst values with a zero (or nil) src time, even with WithOverride or
+// WithOverwriteWithEmptyValue, protecting fields like CreatedAt.
+func WithSkipZeroTimeOverwrite(config *Config) {
+	config.skipZeroTimeOverwrite = true
+}
+
+// WithWriterFields will make merge and Map write src into the dst values that
+// implement io.Writer, like a *bytes.Buffer or a log sink, instead of
+// assigning them, so they accumulate data. src must hold bytes: a []byte, a
+// string, or a value with a Bytes method like another *bytes.Buffer.
+func WithWriterFields(config *Config) {
+	config.writerFields = true
+}
+
+// WithBinaryUnmarshal will make Map decode the []byte values of a map into the
+// struct fields implementing encoding.BinaryUnmarshaler with UnmarshalBinary,
+// instead of failing on the kind mismatch.
+func WithBinaryUnmarshal(config *Config) {
+	config.binaryUnmarshal = true
+}
+
+// WithPreserveNumericType will make merge convert numeric map values into the
+// numeric type of the dst value they replace, like the float64 numbers decoded
+// from JSON into the ints of a config map. Values that don't fit exactly in the
+// dst type are kept as they are.
+func WithPreserveNumericType(config *Config) {
+	config.preserveNumericType = true
+}
+
+// WithSkipEmptyStructs will make merge consider empty any struct whose fields are
+// all empty, recursively, so a zero struct in src never overwrites dst.
+func WithSkipEmptyStructs(config *Config) {
+	config.skipEmptyStructs = true
+}
+
+// WithSkipEqualSubtrees will make merge skip the structs deeply equal in dst
+// and src without looking into their fields, nor calling transformers or
+// hooks for them, which speeds up merging mostly unchanged values.
+func WithSkipEqualSubtrees(config *Config) {
+	config.skipEqualSubtrees = true
+}
+
+// WithMapSliceMergeKey will make merge match the maps inside slices of maps by
+// the value they hold under key, deep merging the matching ones and appending
+// the rest.
+func WithMapSliceMergeKey(key string) func(*Config) {
+	return func(config *Config) {
+		config.mapSliceMergeKey = key
+	}
+}
+
+// WithSliceMergeByKeys will make merge match the elements of slices of structs
+// (or pointers to structs) and of maps by the values they hold under all the
+// given fields, deep merging the matching ones and appending the rest.
+func WithSliceMergeByKeys(keys ...string) func(*Config) {
+	return func(config *Config) {
+		config.sliceMergeKeys = keys
+	}
+}
+
+// WithSliceDeepMergeByKey will make merge match the elements of slices of
+// structs (or pointers to structs) by the value they hold under fieldName, like
+// WithSliceMergeByKeys. Elements whose key is the zero value are appended, as
+// if they had no key.
+func WithSliceDeepMergeByKey(fieldName string) func(*Config) {
+	return func(config *Config) {
+		config.sliceDeepMergeKey = fieldName
+	}
+}
+
+// WithSliceMergeByKeyTombstone will make a by-key slice merge remove the dst
+// element matching a src element that holds a non-empty value (like true)
+// under fieldName, instead of merging them. Tombstones without a match are
+// dropped, so one merge can add, update and delete elements.
+func WithSliceMergeByKeyTombstone(fieldName string) func(*Config) {
+	return func(config *Config) {
+		config.sliceMergeByKeyTombstone = fieldName
+	}
+}
+
+// WithElementConflictResolver will make by-key and WithSliceDeepCopy slice
+// merges call resolve when both the dst and src elements matched are non-empty,
+// storing the element it returns instead of deep merging them, like to keep the
+// one with the newest timestamp.
+func WithElementConflictResolver(resolve func(dst, src reflect.Value) (reflect.Value, error)) func(*Config) {
+	return func(config *Config) {
+		config.elementConflictResolver = resolve
+	}
+}
+
+// WithMostCompleteWins will make merge choose between two non-empty structs of
+// the same type held by interfaces, or matched as slice elements like with
+// WithElementConflictResolver, by keeping the one with the most non-empty
+// fields, counted recursively, instead of merging them. Ties keep dst.
+func WithMostCompleteWins(config *Config) {
+	config.mostCompleteWins = true
+}
+
+// WithCanonicalizePointers will make merge bring a src value to the shape of the
+// dst one when one is a pointer to the type of the other: a value bound by Map
+// to a pointer field is merged as a pointer to a copy of it, and interfaces
+// holding a pointer and a value of the same type are merged through it.
+func WithCanonicalizePointers(config *Config) {
+	config.canonicalizePointers = true
+}
+
+// WithStrictInterfaces will make merge fail with ErrNilInterfaceDestination
+// when it should merge into a nil interface, as there is no type to merge into,
+// unless a factory was registered for it using WithInterfaceFactory.
+func WithStrictInterfaces(config *Config) {
+	config.strictInterfaces = true
+}
+
+// WithInterfaceFactory registers a factory building the value to merge into
+// when a dst value of the interface type typ is nil. The factory should return
+// a pointer to the concrete type held by src.
+func WithInterfaceFactory(typ reflect.Type, factory func() interface{}) func(*Config) {
+	return func(config *Config) {
+		if config.interfaceFactories == nil {
+			config.interfaceFactories = make(map[reflect.Type]func() interface{})
+		}
+		config.interfaceFactories[typ] = factory
+	}
+}
+
+// WithMaxVisitedEntries will make merge and Map fail with ErrTooManyNodes
+// when tracking more than n distinct values for cycle detection, bounding its
+// memory on untrusted input.
+func WithMaxVisitedEntries(n int) func(*Config) {
+	return func(config *Config) {
+		config.maxVisitedEntries = n
+	}
+}
+
+// WithMaxFields will make merge fail with ErrTooManyFields after processing more
+// than n fields and map keys in total, guarding against untrusted input.
+func WithMaxFields(n int) func(*Config) {
+	return func(config *Config) {
+		config.maxFields = n
+	}
+}
+
+// WithMaxBytes will make merge fail with ErrByteBudgetExceeded once the bytes
+// allocated by deep copies and appends exceed n. They are estimated from the
+// size of the copied elements times their count.
+func WithMaxBytes(n int64) func(*Config) {
+	return func(config *Config) {
+		config.maxBytes = n
+	}
+}
+
+// WithParallelism will make merge use n goroutines to merge the top-level fields
+// of structs concurrently. It is experimental, and only safe when those fields
+// don't share any pointed value and the transformers are safe for concurrent
+// use.
+func WithParallelism(n int) func(*Config) {
+	return func(config *Config) {
+		config.parallelism = n
+	}
+}
+
+// WithAggregateTransformerErrors will make merge go on when a transformer fails,
+// returning all the transformer errors together as Errors once it finishes.
+// Each of them is a *TransformerError holding the path of the failing value.
+func WithAggregateTransformerErrors(config *Config) {
+	config.aggregateTransformerErrors = true
+}
+
+// WithIgnoreUnexportedTypes will make merge skip fields whose type is unexported
+// by its package, like third-party internals that can't be merged safely.
+// Pointers, slices, arrays, channels and maps of those types are skipped too.
+func WithIgnoreUnexportedTypes(config *Config) {
+	config.ignoreUnexportedTypes = true
+}
+
+// WithCopyUnsafePointers will make merge copy uintptr and unsafe.Pointer values,
+// which are skipped by default. They are copied as is, following the same
+// rules as any other scalar value.
+func WithCopyUnsafePointers(config *Config) {
+	config.copyUnsafePointers = true
+}
+
+// WithPanicOnTypeMismatch will make merge and Map panic on type mismatches,
+// with the path and types involved, instead of returning an error, so the
+// stack trace points at the offending call while debugging.
+func WithPanicOnTypeMismatch(config *Config) {
+	config.panicOnTypeMismatch = true
+}
+
+// WithErrorFormatter will make merge render the message of the type mismatches
+// it returns, as *TypeMismatchError even at the top level, with format.
+func WithErrorFormatter(format func(*TypeMismatchError) string) func(*Config) {
+	return func(config *Config) {
+		config.errorFormatter = format
+	}
+}
+
+// WithErrorAccumulation will make merge go on when it finds a type mismatch
+// below the top level, skipping the mismatched value, and return all of them
+// together as Errors once it finishes.
+func WithErrorAccumulation(config *Config) {
+	config.accumulateErrors = true
+}
+
+// WithDisableCycleDetection will make merge skip the tracking of the values
+// already visited, saving its allocations. It is only meant for trusted data
+// known to be acyclic, as merging cyclic values recurses forever.
+func WithDisableCycleDetection(config *Config) {
+	config.disableCycleDetection = true
+}
+
+// WithDeterministicOrder will make merge and Map iterate map keys in sorted
+// order, so transformer calls, errors and anything else depending on the
+// order are reproducible between runs.
+func WithDeterministicOrder(config *Config) {
+	config.deterministicOrder = true
+}
+
+// WithDefaultsForEmpty will make merge and Map set the given defaults, keyed
+// by path like Spec.Labels[app].Name, into the dst values still empty once
+// merged, filling what neither dst nor src had. Defaults must be assignable to
+// the values they are set into.
+func WithDefaultsForEmpty(defaults map[string]interface{}) func(*Config) {
+	return func(config *Config) {
+		config.defaults = defaults
+	}
+}
+
+// WithSchemaValidation will make merge and Map check the validate tags of dst
+// fields once merged, returning the violations as Errors of *ValidationError.
+// Rules are comma-separated: required, nonempty (for strings, slices and
+// maps), and min=N and max=N, bounding numbers or lengths.
+func WithSchemaValidation(config *Config) {
+	config.schemaValidation = true
+}
+
+// WithDebugDumpOnError will make merge wrap errors in a *DumpError holding the
+// path and a truncated dump of the dst and src values where they happened.
+func WithDebugDumpOnError(config *Config) {
+	config.debugDumpOnError = true
+}
+
+// WithMaxErrorValueLen will make the dumps of WithDebugDumpOnError be truncated
+// to n bytes, followed by an ellipsis, instead of the default 256.
+func WithMaxErrorValueLen(n int) func(*Config) {
+	return func(config *Config) {
+		config.maxErrorValueLen = n
+	}
+}
+
+// WithIterativeMapMerge will make merge process nested maps using an explicit
+// queue instead of recursion, so extremely deep map trees can't exhaust the stack.
+func WithIterativeMapMerge(config *Config) {
+	config.iterativeMapMerge = true
+}
+
 func merge(dst, src interface{}, opts ...func(*Config)) error {
+	config := &Config{}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return mergeWithConfig(dst, src, config)
+}
+
+// MergeWithConfig does the same as Merge but using config, which can be built
+// by hand instead of through options, see Config. config is reset before the
+// merge, so it can be reused, and holds the effective options afterwards.
+func MergeWithConfig(dst, src interface{}, config *Config) error {
+	if config == nil {
+		config = &Config{}
+	}
+	resetCallState(config)
+	return mergeWithConfig(dst, src, config)
+}
+
+// finishMerge returns err along with the errors collected during the merge of
+// dst, or the error of the finalizer given by WithFinalizer if there are none.
+func finishMerge(dst interface{}, err error, config *Config) error {
+	if err = collectErrors(config, err); err == nil && config.finalizer != nil {
+		err = config.finalizer(dst)
+	}
+	return err
+}
+
+// resetCallState resets the state of config tracking a single merge, like the
+// counters checked against the limits of WithMaxFields and WithMaxBytes.
+func resetCallState(config *Config) {
+	config.fieldCount, config.copiedBytes, config.path, config.errors, config.mapQueue, config.trace = 0, 0, nil, nil, nil, nil
+}
+
+func mergeWithConfig(dst, src interface{}, config *Config) error {
+	if config.profileConflict {
+		return ErrConflictingProfiles
+	}
 	if dst != nil && reflect.ValueOf(dst).Kind() != reflect.Ptr {
 		return ErrNonPointerAgument
 	}
@@ -350,20 +1530,224 @@ func merge(dst, src interface{}, opts ...func(*Config)) error {
 		vDst, vSrc reflect.Value
 		err        error
 	)
+	if dst != nil && src != nil {
+		if vDst = reflect.ValueOf(dst).Elem(); vDst.Kind() == reflect.Interface {
+			return finishMerge(dst, deepMergeInterface(vDst, reflect.ValueOf(src), config), config)
+		}
+	}
+	if vDst, vSrc, err = resolveValues(dst, src); err != nil {
+		return err
+	}
+	if vDst.Type() != vSrc.Type() && (config.matchTag == "" || vDst.Kind() != reflect.Struct || vSrc.Kind() != reflect.Struct) {
+		return typeMismatch(config, ErrDifferentArgumentsTypes, vDst.Type(), vSrc.Type())
+	}
+	var srcHash uint64
+	if config.srcCache != nil {
+		srcHash = structuralHash(vSrc)
+		config.srcCache.mu.Lock()
+		defer config.srcCache.mu.Unlock()
+		if config.srcCache.changed = !config.srcCache.valid || config.srcCache.hash != srcHash; !config.srcCache.changed {
+			return nil
+		}
+	}
+	if err = deepMerge(vDst, vSrc, newVisited(vDst), 0, config); err == nil && len(config.defaults) > 0 {
+		err = applyDefaults(vDst, config)
+	}
+	if err == nil && config.schemaValidation {
+		err = validate(vDst, make(map[uintptr]bool), config)
+	}
+	if err = finishMerge(dst, err, config); err == nil && config.srcCache != nil {
+		config.srcCache.hash, config.srcCache.valid = srcHash, true
+	}
+	return err
+}
 
-	config := &Config{}
+// deepMergeInterface merges src into dst, an interface passed by pointer. A nil
+// dst is set to a copy of src, while a dst holding src's type (or a pointer to
+// it) is deep merged. Values held by the interface aren't addressable, so they
+// are merged into a copy that is stored back.
+func deepMergeInterface(dst, src reflect.Value, config *Config) error {
+	concrete := src
+	if concrete.Kind() == reflect.Ptr {
+		concrete = concrete.Elem()
+	}
+	if concrete.Kind() != reflect.Struct && concrete.Kind() != reflect.Map {
+		return ErrNotSupported
+	}
+	visited := make(map[visit]bool)
+	if dst.IsNil() {
+		clone := reflect.New(concrete.Type())
+		if err := deepMerge(clone.Elem(), concrete, visited, 0, config); err != nil {
+			return err
+		}
+		if src.Kind() != reflect.Ptr {
+			clone = clone.Elem()
+		}
+		if !clone.Type().AssignableTo(dst.Type()) {
+			return ErrDifferentArgumentsTypes
+		}
+		dst.Set(clone)
+		return nil
+	}
+	held := dst.Elem()
+	if held.Kind() == reflect.Ptr {
+		if held.IsNil() || held.Elem().Type() != concrete.Type() {
+			return ErrDifferentArgumentsTypes
+		}
+		return deepMerge(held.Elem(), concrete, visited, 0, config)
+	}
+	if held.Type() != concrete.Type() {
+		return ErrDifferentArgumentsTypes
+	}
+	copied := reflect.New(held.Type()).Elem()
+	copied.Set(held)
+	if err := deepMerge(copied, concrete, visited, 0, config); err != nil {
+		return err
+	}
+	dst.Set(copied)
+	return nil
+}
 
-	for _, opt := range opts {
-		opt(config)
+// transformMapElement runs the transformer registered for the concrete type of
+// srcElement, if any, and stores its result in dst under key. Map values
+// aren't addressable, so the transformer works on a copy of the current one.
+func transformMapElement(dst, key, srcElement reflect.Value, config *Config) (bool, error) {
+	if !hasTransformers(config) {
+		return false, nil
 	}
+	src := reflect.ValueOf(srcElement.Interface())
+	fn := transformerFor(src.Type(), config)
+	if fn == nil || !src.Type().AssignableTo(dst.Type().Elem()) {
+		return false, nil
+	}
+	elem := reflect.New(src.Type()).Elem()
+	if current := dst.MapIndex(key); current.IsValid() {
+		if current.Kind() == reflect.Interface {
+			current = current.Elem()
+		}
+		if current.IsValid() && current.Type() == src.Type() {
+			elem.Set(current)
+		}
+	}
+	if err := fn(elem, src); err != nil {
+		if !config.aggregateTransformerErrors {
+			return true, err
+		}
+		config.errors = append(config.errors, &TransformerError{Path: currentPath(config), Err: err})
+		return true, nil
+	}
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMap(dst.Type()))
+	}
+	dst.SetMapIndex(key, elem)
+	return true, nil
+}
 
-	if vDst, vSrc, err = resolveValues(dst, src); err != nil {
+// mustOverwrite reports whether non-empty src values of typ override dst ones:
+// if it is one of WithOverrideTypes types, or under WithOverride otherwise.
+func mustOverwrite(typ reflect.Type, config *Config) bool {
+	if config.overrideTypes == nil {
+		return config.Overwrite
+	}
+	return config.overrideTypes[typ]
+}
+
+// typeMismatch returns err, a mismatch between dst and src types found at the
+// current path, or panics with it under WithPanicOnTypeMismatch.
+func typeMismatch(config *Config, err error, dst, src reflect.Type) error {
+	if config.panicOnTypeMismatch {
+		panic(fmt.Sprintf("mergo: %v at %q (dst: %s, src: %s)", err, currentPath(config), dst, src))
+	}
+	path := currentPath(config)
+	if path == "" && config.errorFormatter == nil {
+		// Top level mismatches are about the arguments themselves.
 		return err
 	}
-	if vDst.Type() != vSrc.Type() {
-		return ErrDifferentArgumentsTypes
+	mismatch := &TypeMismatchError{Path: path, Dst: dst, Src: src, DstKind: dst.Kind(), SrcKind: src.Kind(), Err: err, format: config.errorFormatter}
+	if config.accumulateErrors && path != "" {
+		config.errors = append(config.errors, mismatch)
+		return nil
+	}
+	return mismatch
+}
+
+// dumpOnError wraps *err, if any, with a dump of the values being merged. Only
+// the innermost failing call wraps it, as that is where it happened.
+func dumpOnError(err *error, dst, src reflect.Value, config *Config) {
+	if *err == nil {
+		return
+	}
+	var dumpErr *DumpError
+	if errors.As(*err, &dumpErr) {
+		return
+	}
+	maxLen := maxDumpLen
+	if config.maxErrorValueLen > 0 {
+		maxLen = config.maxErrorValueLen
+	}
+	*err = &DumpError{Path: currentPath(config), Dst: dumpValue(dst, maxLen), Src: dumpValue(src, maxLen), Err: *err}
+}
+
+// collectErrors returns err, or the errors collected by config during the
+// merge if it didn't fail.
+func collectErrors(config *Config, err error) error {
+	if err == nil && len(config.errors) > 0 {
+		return Errors(config.errors)
+	}
+	return err
+}
+
+// isTypedNilCollection reports whether v is an interface holding a nil slice or map.
+func isTypedNilCollection(v reflect.Value) bool {
+	if v.Kind() != reflect.Interface {
+		return false
+	}
+	v = v.Elem()
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil()
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// isTimeType reports whether typ is time.Time or *time.Time.
+func isTimeType(typ reflect.Type) bool {
+	return typ == timeType || typ == reflect.PtrTo(timeType)
+}
+
+// isZeroTime reports whether v holds a zero time.Time, or a nil *time.Time.
+func isZeroTime(v reflect.Value) bool {
+	v = unwrapInterface(v)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v.Type() == reflect.PtrTo(timeType)
+		}
+		v = v.Elem()
+	}
+	if v.Type() != timeType || !v.CanInterface() {
+		return false
+	}
+	return v.Interface().(time.Time).IsZero()
+}
+
+// isLeafValue reports whether v is decided as a whole by WithShouldOverwrite,
+// instead of being merged through its fields, keys or pointed value.
+func isLeafValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Invalid, reflect.Map, reflect.Ptr, reflect.Interface:
+		return false
+	case reflect.Struct:
+		return !hasMergeableFields(v)
+	}
+	return true
+}
+
+// isSetReference reports whether v is a non-nil slice, map or pointer, even if
+// it is empty.
+func isSetReference(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Ptr:
+		return !v.IsNil()
 	}
-	return deepMerge(vDst, vSrc, make(map[uintptr]*visit), 0, config)
+	return false
 }
 
 // IsReflectNil is the reflect value provided nil
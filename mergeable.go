@@ -0,0 +1,47 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+// Mergeable is implemented by the types merging other values of theirs on
+// their own. When a map holds them under a key found in both dst and src,
+// merge calls MergeFrom on the dst value with the src one instead of
+// overwriting it.
+type Mergeable interface {
+	MergeFrom(src interface{}) error
+}
+
+var mergeableType = reflect.TypeOf((*Mergeable)(nil)).Elem()
+
+// mergeMapElementMergeable merges srcElement into the value stored under key in
+// dst with its MergeFrom method if it is a Mergeable found in both maps. It
+// reports whether the value was merged.
+func mergeMapElementMergeable(dst, key, dstElement, srcElement reflect.Value, config *Config) (bool, error) {
+	dstValue, srcValue := unwrapInterface(dstElement), unwrapInterface(srcElement)
+	if !dstValue.IsValid() || !srcValue.IsValid() || dstValue.Type() != srcValue.Type() || !srcValue.CanInterface() {
+		return false, nil
+	}
+	typ := dstValue.Type()
+	if typ.Kind() == reflect.Ptr && typ.Implements(mergeableType) {
+		if dstValue.IsNil() {
+			return false, nil
+		}
+		traceDecision(config, TraceSet, dstElement, srcElement)
+		return true, dstValue.Interface().(Mergeable).MergeFrom(srcValue.Interface())
+	}
+	if !reflect.PtrTo(typ).Implements(mergeableType) {
+		return false, nil
+	}
+	// Map values aren't addressable, so merge a copy and store it back.
+	merged := reflect.New(typ)
+	merged.Elem().Set(dstValue)
+	if err := merged.Interface().(Mergeable).MergeFrom(srcValue.Interface()); err != nil {
+		return true, err
+	}
+	traceDecision(config, TraceSet, dstElement, merged.Elem())
+	dst.SetMapIndex(key, merged.Elem())
+	return true, nil
+}
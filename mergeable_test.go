@@ -0,0 +1,67 @@
+package mergo_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type hitCounter struct {
+	Hits    int
+	Sources []string
+}
+
+func (c *hitCounter) MergeFrom(src interface{}) error {
+	var other hitCounter
+	switch src := src.(type) {
+	case hitCounter:
+		other = src
+	case *hitCounter:
+		other = *src
+	default:
+		return errors.New("not a counter")
+	}
+	if other.Hits < 0 {
+		return errors.New("negative hits")
+	}
+	c.Hits += other.Hits
+	c.Sources = append(c.Sources, other.Sources...)
+	return nil
+}
+
+func TestMergeableMapValues(t *testing.T) {
+	dst := map[string]hitCounter{"home": {1, []string{"dst"}}, "about": {2, []string{"dst"}}}
+	src := map[string]hitCounter{"home": {3, []string{"src"}}, "blog": {4, []string{"src"}}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]hitCounter{
+		"home":  {4, []string{"dst", "src"}},
+		"about": {2, []string{"dst"}},
+		"blog":  {4, []string{"src"}},
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestMergeableMapPointers(t *testing.T) {
+	home := &hitCounter{Hits: 1}
+	dst := map[string]*hitCounter{"home": home}
+	src := map[string]*hitCounter{"home": {Hits: 2}}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst["home"] != home || home.Hits != 3 {
+		t.Errorf("expected the dst counter to be merged in place, got %v", dst["home"])
+	}
+
+	src = map[string]*hitCounter{"home": {Hits: -1}}
+	if err := mergo.Merge(&dst, src); err == nil || err.Error() != "negative hits" {
+		t.Errorf("expected the MergeFrom error, got %v", err)
+	}
+}
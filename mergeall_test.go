@@ -0,0 +1,58 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func TestMergeAll(t *testing.T) {
+	dst := layeredConfig{}
+	srcs := []interface{}{
+		layeredConfig{Name: "first"},
+		&layeredConfig{Name: "second", Port: 80},
+		layeredConfig{Port: 443, Hosts: []string{"a"}},
+	}
+
+	if err := mergo.MergeAll(&dst, srcs...); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (layeredConfig{Name: "first", Port: 80, Hosts: []string{"a"}}); !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected earlier sources to win, got %+v", dst)
+	}
+}
+
+func TestMergeAllWithOptions(t *testing.T) {
+	dst := layeredConfig{Name: "dst"}
+	srcs := []interface{}{
+		layeredConfig{Name: "first", Hosts: []string{"a"}},
+		layeredConfig{Name: "second", Hosts: []string{"b"}},
+	}
+
+	if err := mergo.MergeAllWithOptions(&dst, srcs, mergo.WithOverride, mergo.WithAppendSlice); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (layeredConfig{Name: "second", Hosts: []string{"a", "b"}}); !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected later sources to win, got %+v", dst)
+	}
+}
+
+func TestMergeAllMaxFieldsPerSource(t *testing.T) {
+	dst := layeredConfig{}
+	srcs := []interface{}{layeredConfig{Name: "a"}, layeredConfig{Port: 1}, layeredConfig{}}
+	if err := mergo.MergeAllWithOptions(&dst, srcs, mergo.WithMaxFields(5)); err != nil {
+		t.Errorf("expected field budgets to be reset for every source, got %v", err)
+	}
+}
+
+func TestMergeAllStopsOnError(t *testing.T) {
+	dst := layeredConfig{}
+	err := mergo.MergeAll(&dst, layeredConfig{Name: "first"}, map[string]string{}, layeredConfig{Port: 80})
+	if err != mergo.ErrDifferentArgumentsTypes {
+		t.Fatalf("expected %v, got %v", mergo.ErrDifferentArgumentsTypes, err)
+	}
+	if dst.Name != "first" || dst.Port != 0 {
+		t.Errorf("expected the sources after the error not to be merged, got %+v", dst)
+	}
+}
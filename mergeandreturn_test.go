@@ -0,0 +1,23 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func TestMergeAndReturn(t *testing.T) {
+	dst := &simpleTest{}
+
+	merged, err := mergo.MergeAndReturn(dst, simpleTest{Value: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged != dst || dst.Value != 42 {
+		t.Errorf("expected dst itself to be returned merged, got %v", merged)
+	}
+
+	if _, err := mergo.MergeAndReturn(simpleTest{}, simpleTest{Value: 42}); err != mergo.ErrNonPointerAgument {
+		t.Errorf("expected %v, got %v", mergo.ErrNonPointerAgument, err)
+	}
+}
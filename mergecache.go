@@ -0,0 +1,137 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// MergeCache remembers a structural hash of the last src merged using
+// WithSkipIfSrcUnchanged, so an identical src can be skipped. It is safe for
+// concurrent use, and its zero value is ready to use.
+type MergeCache struct {
+	mu      sync.Mutex
+	hash    uint64
+	valid   bool
+	changed bool
+}
+
+// Changed reports whether the last merge using the cache applied its src,
+// that is whether src was different from the previous one.
+func (c *MergeCache) Changed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.changed
+}
+
+// Reset forgets the last src, so the next merge is applied whatever its src.
+func (c *MergeCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.valid, c.changed = false, false
+}
+
+// WithSkipIfSrcUnchanged will make merge hash src and skip the merge entirely
+// if it is the same as the last src merged with cache, like a configuration
+// reloaded without changes. Only src is compared, so changes made to dst in
+// between are not undone. Unexported fields aren't hashed.
+func WithSkipIfSrcUnchanged(cache *MergeCache) func(*Config) {
+	return func(config *Config) {
+		config.srcCache = cache
+	}
+}
+
+// structuralHash returns a hash of the values held by v, following pointers.
+// Map entries are hashed in the order of their formatted keys.
+func structuralHash(v reflect.Value) uint64 {
+	h := fnv.New64a()
+	hashValue(h, v, make(map[uintptr]bool))
+	return h.Sum64()
+}
+
+func hashValue(h hash.Hash64, v reflect.Value, seen map[uintptr]bool) {
+	var buf [8]byte
+	writeUint := func(u uint64) {
+		binary.LittleEndian.PutUint64(buf[:], u)
+		h.Write(buf[:])
+	}
+	if !v.IsValid() {
+		writeUint(0)
+		return
+	}
+	writeUint(uint64(v.Kind()))
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			writeUint(1)
+		} else {
+			writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint(math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		writeUint(math.Float64bits(real(v.Complex())))
+		writeUint(math.Float64bits(imag(v.Complex())))
+	case reflect.String:
+		writeUint(uint64(v.Len()))
+		h.Write([]byte(v.String()))
+	case reflect.Array, reflect.Slice:
+		writeUint(uint64(v.Len()))
+		for i, n := 0, v.Len(); i < n; i++ {
+			hashValue(h, v.Index(i), seen)
+		}
+	case reflect.Map:
+		writeUint(uint64(v.Len()))
+		type namedKey struct {
+			name string
+			key  reflect.Value
+		}
+		keys := make([]namedKey, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			keys = append(keys, namedKey{fmt.Sprint(key), key})
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i].name < keys[j].name })
+		for _, key := range keys {
+			hashValue(h, key.key, seen)
+			hashValue(h, v.MapIndex(key.key), seen)
+		}
+	case reflect.Struct:
+		for i, n := 0, v.NumField(); i < n; i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				hashValue(h, v.Field(i), seen)
+			}
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			writeUint(0)
+			return
+		}
+		if seen[v.Pointer()] {
+			return
+		}
+		seen[v.Pointer()] = true
+		hashValue(h, v.Elem(), seen)
+		delete(seen, v.Pointer())
+	case reflect.Interface:
+		if !v.IsNil() {
+			h.Write([]byte(v.Elem().Type().String()))
+		}
+		hashValue(h, v.Elem(), seen)
+	default:
+		// Funcs, chans and unsafe pointers are compared by identity.
+		writeUint(uint64(v.Pointer()))
+	}
+}
@@ -0,0 +1,56 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func newOverlay(port int) map[string]interface{} {
+	return map[string]interface{}{
+		"server": map[string]interface{}{"host": "example.com", "port": port},
+		"tags":   []interface{}{"a", "b"},
+	}
+}
+
+func TestSkipIfSrcUnchanged(t *testing.T) {
+	cache := &mergo.MergeCache{}
+	dst := map[string]interface{}{}
+
+	if err := mergo.Merge(&dst, newOverlay(80), mergo.WithOverride, mergo.WithSkipIfSrcUnchanged(cache)); err != nil {
+		t.Fatal(err)
+	}
+	if !cache.Changed() {
+		t.Fatal("expected the first merge to be applied")
+	}
+
+	dst["tags"] = []interface{}{"changed at runtime"}
+	if err := mergo.Merge(&dst, newOverlay(80), mergo.WithOverride, mergo.WithSkipIfSrcUnchanged(cache)); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Changed() {
+		t.Error("expected an identical src to be skipped")
+	}
+	if !reflect.DeepEqual(dst["tags"], []interface{}{"changed at runtime"}) {
+		t.Errorf("expected the skipped merge to leave dst alone, got %v", dst["tags"])
+	}
+
+	if err := mergo.Merge(&dst, newOverlay(8080), mergo.WithOverride, mergo.WithSkipIfSrcUnchanged(cache)); err != nil {
+		t.Fatal(err)
+	}
+	if !cache.Changed() {
+		t.Error("expected a different src to be applied")
+	}
+	if port := dst["server"].(map[string]interface{})["port"]; port != 8080 {
+		t.Errorf("expected port 8080, got %v", port)
+	}
+
+	cache.Reset()
+	if err := mergo.Merge(&dst, newOverlay(8080), mergo.WithOverride, mergo.WithSkipIfSrcUnchanged(cache)); err != nil {
+		t.Fatal(err)
+	}
+	if !cache.Changed() {
+		t.Error("expected a reset cache to apply src")
+	}
+}
@@ -0,0 +1,79 @@
+package mergo_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/imdario/mergo"
+)
+
+type slowValue struct {
+	N int
+}
+
+type slowJob struct {
+	First  slowValue
+	Second slowValue
+	Name   string
+}
+
+type slowTransformers struct {
+	calls int
+}
+
+func (s *slowTransformers) ContextTransformer(typ reflect.Type) func(ctx context.Context, dst, src reflect.Value) error {
+	if typ != reflect.TypeOf(slowValue{}) {
+		return nil
+	}
+	return func(ctx context.Context, dst, src reflect.Value) error {
+		s.calls++
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			dst.Set(src)
+			return nil
+		}
+	}
+}
+
+func (s *slowTransformers) Transformer(reflect.Type) func(dst, src reflect.Value) error {
+	return nil
+}
+
+func TestMergeContextCancelsTransformer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	transformers := &slowTransformers{}
+	dst := slowJob{First: slowValue{1}, Second: slowValue{1}}
+
+	err := mergo.MergeContext(ctx, &dst, slowJob{First: slowValue{2}, Second: slowValue{2}, Name: "job"}, mergo.WithTransformers(transformers))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if transformers.calls != 1 {
+		t.Errorf("expected the merge to stop after the first transformer, got %d calls", transformers.calls)
+	}
+	if dst.Name != "" {
+		t.Errorf("expected no field merged after cancellation, got %q", dst.Name)
+	}
+}
+
+func TestMergeContext(t *testing.T) {
+	dst := slowJob{}
+	if err := mergo.MergeContext(context.Background(), &dst, slowJob{Name: "job"}); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "job" {
+		t.Errorf("expected the merge to complete, got %+v", dst)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := mergo.MergeContext(ctx, &dst, slowJob{Name: "other"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
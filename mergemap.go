@@ -0,0 +1,34 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+// MergeMap merges src into dst and returns it, allocating it if nil. Keys only
+// in src are added, and values found under a key in both follow the same rules
+// as Merge: structs, maps and slices are deep merged, while other values are
+// only replaced when empty in dst, or using WithOverride.
+func MergeMap[K comparable, V any](dst, src map[K]V, opts ...func(*Config)) (map[K]V, error) {
+	if dst == nil {
+		dst = make(map[K]V, len(src))
+	}
+	switch reflect.TypeOf((*V)(nil)).Elem().Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice:
+	default:
+		return dst, merge(&dst, src, opts...)
+	}
+	for key, srcValue := range src {
+		dstValue, ok := dst[key]
+		if !ok {
+			dst[key] = srcValue
+			continue
+		}
+		if err := merge(&dstValue, srcValue, opts...); err != nil {
+			return dst, err
+		}
+		dst[key] = dstValue
+	}
+	return dst, nil
+}
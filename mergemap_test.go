@@ -0,0 +1,64 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func TestMergeMapScalars(t *testing.T) {
+	dst := map[string]int{"a": 1, "b": 0}
+	src := map[string]int{"a": 2, "b": 3, "c": 4}
+
+	merged, err := mergo.MergeMap(dst, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]int{"a": 1, "b": 3, "c": 4}; !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+
+	merged, err = mergo.MergeMap(nil, src, mergo.WithOverride)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(merged, src) {
+		t.Errorf("expected %v, got %v", src, merged)
+	}
+}
+
+func TestMergeMapStructs(t *testing.T) {
+	dst := map[string]simpleTest{"a": {Value: 1}, "b": {}}
+	src := map[string]simpleTest{"a": {Value: 2}, "b": {Value: 3}, "c": {Value: 4}}
+
+	merged, err := mergo.MergeMap(dst, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]simpleTest{"a": {Value: 1}, "b": {Value: 3}, "c": {Value: 4}}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+
+	merged, err = mergo.MergeMap(dst, src, mergo.WithOverride)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(merged, src) {
+		t.Errorf("expected %v, got %v", src, merged)
+	}
+}
+
+func TestMergeMapSlices(t *testing.T) {
+	dst := map[int][]string{1: {"a"}}
+	src := map[int][]string{1: {"b"}, 2: {"c"}}
+
+	merged, err := mergo.MergeMap(dst, src, mergo.WithAppendSlice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[int][]string{1: {"a", "b"}, 2: {"c"}}; !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+}
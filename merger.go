@@ -0,0 +1,41 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+// Merger merges values with the same options, applied once when it is built,
+// along many calls. It is safe for concurrent use, as every call keeps its own
+// counters, path and trace.
+type Merger struct {
+	config Config
+}
+
+// NewMerger returns a Merger merging with the given options.
+func NewMerger(opts ...func(*Config)) *Merger {
+	m := &Merger{}
+	for _, opt := range opts {
+		opt(&m.config)
+	}
+	return m
+}
+
+// Merge does the same as Merge with the options of m. Limits like the ones of
+// WithMaxFields and WithMaxBytes apply to each call on its own, not to all the
+// calls made so far.
+func (m *Merger) Merge(dst, src interface{}) error {
+	config := m.config
+	resetCallState(&config)
+	return mergeWithConfig(dst, src, &config)
+}
+
+// MergeWithTrace does the same as MergeWithTrace with the options of m. Every
+// call returns the decisions taken by it alone.
+func (m *Merger) MergeWithTrace(dst, src interface{}) ([]TraceEntry, error) {
+	config := m.config
+	resetCallState(&config)
+	var entries []TraceEntry
+	config.trace = &entries
+	err := mergeWithConfig(dst, src, &config)
+	return entries, err
+}
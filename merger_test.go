@@ -0,0 +1,81 @@
+package mergo_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func TestMergerBudgetsPerCall(t *testing.T) {
+	merger := mergo.NewMerger(mergo.WithOverride, mergo.WithMaxFields(5), mergo.WithMaxBytes(64))
+	src := maxFieldsOuter{Name: "src", Port: 1, Inner: maxFieldsInner{1, 2}}
+
+	for i := 0; i < 100; i++ {
+		dst := maxFieldsOuter{Name: "dst"}
+		if err := merger.Merge(&dst, src); err != nil {
+			t.Fatalf("call %d: expected each call to stay within its budgets, got %v", i, err)
+		}
+		if dst != src {
+			t.Fatalf("call %d: expected %v, got %v", i, src, dst)
+		}
+	}
+
+	// Every call copies the 8000 bytes of the index.
+	blobMerger := mergo.NewMerger(mergo.WithMapDeepCopy, mergo.WithMaxBytes(20000))
+	for i := 0; i < 10; i++ {
+		dst := blobs{}
+		if err := blobMerger.Merge(&dst, newBlobs(10)); err != nil {
+			t.Fatalf("call %d: expected each copy to fit in the budget, got %v", i, err)
+		}
+	}
+	dst := blobs{}
+	if err := blobMerger.Merge(&dst, newBlobs(30)); !errors.Is(err, mergo.ErrByteBudgetExceeded) {
+		t.Errorf("expected %v beyond the budget of a call, got %v", mergo.ErrByteBudgetExceeded, err)
+	}
+}
+
+func TestMergerConcurrentCalls(t *testing.T) {
+	merger := mergo.NewMerger(mergo.WithMaxFields(5))
+	src := maxFieldsOuter{Name: "src", Port: 1, Inner: maxFieldsInner{1, 2}}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var dst maxFieldsOuter
+			if err := merger.Merge(&dst, src); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMergerTracePerCall(t *testing.T) {
+	merger := mergo.NewMerger(mergo.WithOverride)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(port int) {
+			defer wg.Done()
+			dst := maxFieldsOuter{Name: "dst"}
+			trace, err := merger.MergeWithTrace(&dst, maxFieldsOuter{Name: "src", Port: port})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			var ports []interface{}
+			for _, entry := range trace {
+				if entry.Path == "Port" {
+					ports = append(ports, entry.To)
+				}
+			}
+			if len(ports) != 1 || ports[0] != port {
+				t.Errorf("expected the trace of the call merging port %d, got %v", port, ports)
+			}
+		}(i + 1)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,68 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type layeredConfig struct {
+	Name   string
+	Port   int
+	Hosts  []string
+	Labels map[string]string
+	TLS    *layeredTLS
+}
+
+type layeredTLS struct {
+	Cert string
+	Key  string
+}
+
+func newBaseLayer() layeredConfig {
+	return layeredConfig{Name: "base", Port: 80, Hosts: []string{"a"}, Labels: map[string]string{"env": "dev"}, TLS: &layeredTLS{Cert: "base.crt"}}
+}
+
+func newOverlayLayer() layeredConfig {
+	return layeredConfig{Port: 443, Hosts: []string{"b"}, Labels: map[string]string{"team": "core"}, TLS: &layeredTLS{Key: "overlay.key"}}
+}
+
+func TestMergeTo(t *testing.T) {
+	a, b := newBaseLayer(), newOverlayLayer()
+	var out layeredConfig
+
+	if err := mergo.MergeTo(&out, a, &b, mergo.WithOverride, mergo.WithAppendSlice); err != nil {
+		t.Fatal(err)
+	}
+	expected := layeredConfig{
+		Name:   "base",
+		Port:   443,
+		Hosts:  []string{"a", "b"},
+		Labels: map[string]string{"env": "dev", "team": "core"},
+		TLS:    &layeredTLS{Cert: "base.crt", Key: "overlay.key"},
+	}
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("expected %+v, got %+v", expected, out)
+	}
+	if !reflect.DeepEqual(a, newBaseLayer()) || !reflect.DeepEqual(b, newOverlayLayer()) {
+		t.Errorf("expected a and b to be untouched, got %+v and %+v", a, b)
+	}
+
+	out.Labels["new"] = "x"
+	out.TLS.Cert = "changed"
+	if len(a.Labels) != 1 || len(b.Labels) != 1 || a.TLS.Cert != "base.crt" {
+		t.Error("expected out not to share storage with a and b")
+	}
+}
+
+func TestMergeToErrors(t *testing.T) {
+	a := newBaseLayer()
+	var out map[string]string
+	if err := mergo.MergeTo(&out, a, a); err != mergo.ErrDifferentArgumentsTypes {
+		t.Errorf("expected %v, got %v", mergo.ErrDifferentArgumentsTypes, err)
+	}
+	if err := mergo.MergeTo(layeredConfig{}, a, a); err != mergo.ErrNonPointerAgument {
+		t.Errorf("expected %v, got %v", mergo.ErrNonPointerAgument, err)
+	}
+}
@@ -0,0 +1,47 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type serviceState struct {
+	Name     string
+	Replicas int
+}
+
+// service keeps its state unexported, exposing it through an accessor.
+type service struct {
+	state serviceState
+}
+
+func (s *service) Mutable() *serviceState {
+	return &s.state
+}
+
+func mutableState(v interface{}) interface{} {
+	return v.(*service).Mutable()
+}
+
+func TestMergeVia(t *testing.T) {
+	svc := &service{state: serviceState{Name: "api"}}
+
+	if err := mergo.MergeVia(svc, mutableState, serviceState{Name: "web", Replicas: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (serviceState{Name: "api", Replicas: 3}); svc.state != expected {
+		t.Errorf("expected %+v, got %+v", expected, svc.state)
+	}
+	if err := mergo.MergeVia(svc, mutableState, serviceState{Name: "web"}, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if svc.state.Name != "web" {
+		t.Errorf("expected options to apply, got %+v", svc.state)
+	}
+
+	nilState := func(interface{}) interface{} { return (*serviceState)(nil) }
+	if err := mergo.MergeVia(svc, nilState, serviceState{}); err != mergo.ErrNilArguments {
+		t.Errorf("expected %v for a nil target, got %v", mergo.ErrNilArguments, err)
+	}
+}
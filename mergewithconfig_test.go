@@ -0,0 +1,55 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func TestMergeWithConfig(t *testing.T) {
+	config := &mergo.Config{Overwrite: true, AppendSlice: true}
+	dst := layeredConfig{Name: "dst", Hosts: []string{"a"}}
+	src := layeredConfig{Name: "src", Hosts: []string{"b"}}
+
+	if err := mergo.MergeWithConfig(&dst, src, config); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (layeredConfig{Name: "src", Hosts: []string{"a", "b"}}); !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+	if !config.Overwrite || !config.AppendSlice {
+		t.Errorf("expected config to keep its options, got %+v", config)
+	}
+}
+
+func TestMergeWithConfigOptions(t *testing.T) {
+	config := &mergo.Config{}
+	mergo.WithOverride(config)
+	mergo.WithMaxFields(5)(config)
+
+	for i := 0; i < 2; i++ {
+		dst := layeredConfig{Name: "dst"}
+		if err := mergo.MergeWithConfig(&dst, layeredConfig{Name: "src"}, config); err != nil {
+			t.Fatalf("expected config to be reusable, got %v on merge %d", err, i)
+		}
+		if dst.Name != "src" {
+			t.Errorf("expected options applied to config to be used, got %+v", dst)
+		}
+	}
+
+	big := struct{ A, B, C, D, E, F int }{}
+	if err := mergo.MergeWithConfig(&big, big, config); err != mergo.ErrTooManyFields {
+		t.Errorf("expected %v, got %v", mergo.ErrTooManyFields, err)
+	}
+}
+
+func TestMergeWithNilConfig(t *testing.T) {
+	dst := layeredConfig{}
+	if err := mergo.MergeWithConfig(&dst, layeredConfig{Name: "src"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "src" {
+		t.Errorf("expected the default options to be used, got %+v", dst)
+	}
+}
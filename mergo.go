@@ -10,7 +10,10 @@ package mergo
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
+	"unicode/utf8"
 )
 
 // Errors reported by Mergo when it finds invalid arguments.
@@ -21,25 +24,143 @@ var (
 	ErrExpectedMapAsDestination    = errors.New("dst was expected to be a map")
 	ErrExpectedStructAsDestination = errors.New("dst was expected to be a struct")
 	ErrNonPointerAgument           = errors.New("dst must be a pointer")
+	ErrNilInterfaceDestination     = errors.New("cannot merge into a nil interface without a registered factory")
+	ErrTooManyFields               = errors.New("too many fields to merge")
+	ErrTooManyNodes                = errors.New("too many values to track while merging")
+	ErrByteBudgetExceeded          = errors.New("too many bytes copied while merging")
+	ErrInvalidValue                = errors.New("src has an invalid value")
+	ErrMissingRequiredField        = errors.New("src is missing a required field")
+	ErrUnknownDiscriminator        = errors.New("unknown discriminator")
+	ErrConflictingProfiles         = errors.New("conflicting merge profiles")
+	ErrConflictingOptions          = errors.New("conflicting merge options")
+	ErrSliceLengthMismatch         = errors.New("dst and src slices must have the same length")
+	ErrKeyCollision                = errors.New("src keys collide once normalized")
 )
 
+// TransformerError is an error returned by a transformer, along with the path
+// of the value it was merging.
+type TransformerError struct {
+	Path string
+	Err  error
+}
+
+func (e *TransformerError) Error() string {
+	return fmt.Sprintf("transformer failed on %s: %v", e.Path, e.Err)
+}
+
+func (e *TransformerError) Unwrap() error {
+	return e.Err
+}
+
+// TypeMismatchError is a mismatch between the dst and src types found at Path,
+// like Spec.Listeners[2].Port, as returned for any value below the top level
+// but the fields Map binds directly, which keep naming the field in a plain
+// error.
+type TypeMismatchError struct {
+	Path    string
+	Dst     reflect.Type
+	Src     reflect.Type
+	DstKind reflect.Kind
+	SrcKind reflect.Kind
+	Err     error
+
+	format func(*TypeMismatchError) string
+}
+
+func (e *TypeMismatchError) Error() string {
+	if e.format != nil {
+		return e.format(e)
+	}
+	if e.Err == ErrDifferentArgumentsTypes {
+		return fmt.Sprintf("cannot merge %s: found %s, expected %s", e.Path, e.Src, e.Dst)
+	}
+	return fmt.Sprintf("cannot merge %s: %v", e.Path, e.Err)
+}
+
+func (e *TypeMismatchError) Unwrap() error {
+	return e.Err
+}
+
+// DumpError is an error along with a dump of the dst and src values being
+// merged where it happened, as returned when WithDebugDumpOnError is used.
+// Dumps are truncated to maxDumpLen bytes, unless WithMaxErrorValueLen is used.
+type DumpError struct {
+	Path string
+	Dst  string
+	Src  string
+	Err  error
+}
+
+const maxDumpLen = 256
+
+func (e *DumpError) Error() string {
+	return fmt.Sprintf("%v at %q (dst: %s, src: %s)", e.Err, e.Path, e.Dst, e.Src)
+}
+
+func (e *DumpError) Unwrap() error {
+	return e.Err
+}
+
+// dumpValue renders v, cut at maxLen bytes, back to the start of the rune
+// there so the dump stays valid UTF-8.
+func dumpValue(v reflect.Value, maxLen int) string {
+	dump := fmt.Sprintf("%+v", v)
+	if len(dump) > maxLen {
+		cut := maxLen
+		for cut > 0 && !utf8.RuneStart(dump[cut]) {
+			cut--
+		}
+		dump = dump[:cut] + "..."
+	}
+	return dump
+}
+
+// Errors is a list of errors collected during a merge that didn't abort it.
+type Errors []error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the collected errors, so errors.Is and errors.As can match
+// any of them.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
 // During deepMerge, must keep track of checks that are
 // in progress.  The comparison algorithm assumes that all
 // checks in progress are true when it reencounters them.
-// Visited are stored in a map indexed by 17 * a1 + a2;
+// Visited are stored in a map indexed by their address and
+// type, as a struct and its first field share the address.
 type visit struct {
-	ptr  uintptr
-	typ  reflect.Type
-	next *visit
+	ptr uintptr
+	typ reflect.Type
 }
 
 // From src/pkg/encoding/json/encode.go.
 func isEmptyValue(v reflect.Value) bool {
 	switch v.Kind() {
-	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Byte arrays (like UUIDs or hashes) are opaque values, empty
+			// when all zeros.
+			for i, n := 0, v.Len(); i < n; i++ {
+				if v.Index(i).Uint() != 0 {
+					return false
+				}
+			}
+			return true
+		}
+		return v.Len() == 0
+	case reflect.Map, reflect.Slice, reflect.String:
 		return v.Len() == 0
 	case reflect.Bool:
-		return false
+		return !v.Bool()
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return v.Int() == 0
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
@@ -51,7 +172,7 @@ func isEmptyValue(v reflect.Value) bool {
 			return true
 		}
 		return isEmptyValue(v.Elem())
-	case reflect.Func:
+	case reflect.Func, reflect.UnsafePointer:
 		return v.IsNil()
 	case reflect.Invalid:
 		return true
@@ -59,20 +180,38 @@ func isEmptyValue(v reflect.Value) bool {
 	return false
 }
 
+// isEmptyStruct reports whether all the fields of the struct v are empty,
+// looking into nested structs recursively.
+func isEmptyStruct(v reflect.Value) bool {
+	for i, n := 0, v.NumField(); i < n; i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			if !isEmptyStruct(field) {
+				return false
+			}
+		} else if !isEmptyValue(field) {
+			return false
+		}
+	}
+	return true
+}
+
 func resolveValues(dst, src interface{}) (vDst, vSrc reflect.Value, err error) {
 	if dst == nil || src == nil {
 		err = ErrNilArguments
 		return
 	}
 	vDst = reflect.ValueOf(dst).Elem()
-	if vDst.Kind() != reflect.Struct && vDst.Kind() != reflect.Map {
+	if vDst.Kind() != reflect.Struct && vDst.Kind() != reflect.Map && vDst.Kind() != reflect.Slice {
 		err = ErrNotSupported
 		return
 	}
 	vSrc = reflect.ValueOf(src)
 	// We check if vSrc is a pointer to dereference it.
 	if vSrc.Kind() == reflect.Ptr {
-		vSrc = vSrc.Elem()
+		if vSrc = vSrc.Elem(); !vSrc.IsValid() {
+			err = ErrNilArguments
+		}
 	}
 	return
 }
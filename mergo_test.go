@@ -350,7 +350,7 @@ func TestEmptyToNotEmptyMaps(t *testing.T) {
 func TestMapsWithOverwrite(t *testing.T) {
 	m := map[string]simpleTest{
 		"a": {},   // overwritten by 16
-		"b": {42}, // overwritten by 0, as map Value is not addressable and it doesn't check for b is set or not set in `n`
+		"b": {42}, // kept, as map values are merged into a copy like fields are
 		"c": {13}, // overwritten by 12
 		"d": {61},
 	}
@@ -362,7 +362,7 @@ func TestMapsWithOverwrite(t *testing.T) {
 	}
 	expect := map[string]simpleTest{
 		"a": {16},
-		"b": {},
+		"b": {42},
 		"c": {12},
 		"d": {61},
 		"e": {14},
@@ -551,7 +551,7 @@ func TestMaps(t *testing.T) {
 		"e": {14},
 	}
 	expect := map[string]simpleTest{
-		"a": {0},
+		"a": {16},
 		"b": {42},
 		"c": {13},
 		"d": {61},
@@ -565,8 +565,8 @@ func TestMaps(t *testing.T) {
 	if !reflect.DeepEqual(m, expect) {
 		t.Errorf("Test failed:\ngot  :\n%#v\n\nwant :\n%#v\n\n", m, expect)
 	}
-	if m["a"].Value != 0 {
-		t.Errorf(`n merged in m because I solved non-addressable map values TODO: m["a"].Value(%d) != n["a"].Value(%d)`, m["a"].Value, n["a"].Value)
+	if m["a"].Value != 16 {
+		t.Errorf(`n not merged in m: m["a"].Value(%d) != n["a"].Value(%d)`, m["a"].Value, n["a"].Value)
 	}
 	if m["b"].Value != 42 {
 		t.Errorf(`n wrongly merged in m: m["b"].Value(%d) != n["b"].Value(%d)`, m["b"].Value, n["b"].Value)
@@ -742,9 +742,8 @@ func TestBackAndForth(t *testing.T) {
 	if v, ok = m["b"]; !ok {
 		t.Errorf("pt not merged in properly: B is missing in m")
 	}
-	var st *simpleTest
-	if st = v.(*simpleTest); st.Value != 66 {
-		t.Errorf("something went wrong while mapping pt on m, B wasn't copied")
+	if st, _ := v.(map[string]interface{}); st["value"] != 66 {
+		t.Errorf("something went wrong while mapping pt on m, B wasn't mapped")
 	}
 	bpt := pointerMapTest{}
 	if err := mergo.Map(&bpt, m); err != nil {
@@ -930,11 +929,11 @@ func TestMergeMapWithInnerSliceOfDifferentType(t *testing.T) {
 	}
 }
 
-func TestMergeSlicesIsNotSupported(t *testing.T) {
+func TestMergeSlicesOfDifferentTypes(t *testing.T) {
 	src := []string{"a", "b"}
 	dst := []int{1, 2}
 
-	if err := mergo.Merge(&src, &dst, mergo.WithOverride, mergo.WithAppendSlice); err != mergo.ErrNotSupported {
-		t.Errorf("expected %q, got %q", mergo.ErrNotSupported, err)
+	if err := mergo.Merge(&src, &dst, mergo.WithOverride, mergo.WithAppendSlice); err != mergo.ErrDifferentArgumentsTypes {
+		t.Errorf("expected %q, got %q", mergo.ErrDifferentArgumentsTypes, err)
 	}
 }
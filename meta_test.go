@@ -0,0 +1,48 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type documentedTLS struct {
+	Cert string `doc:"Path to the certificate"`
+	Key  string
+}
+
+type documentedConfig struct {
+	Name   string `doc:"Name of the service"`
+	Port   int    `doc:"Port to listen on"`
+	Debug  bool
+	TLS    *documentedTLS `doc:"TLS settings"`
+	Parent *documentedConfig
+}
+
+func TestMapWithMeta(t *testing.T) {
+	src := documentedConfig{Name: "api", Port: 8080, TLS: &documentedTLS{Cert: "a.crt"}}
+
+	values, meta, err := mergo.MapWithMeta(&src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["name"] != "api" || values["port"] != 8080 || !reflect.DeepEqual(values["tLS"], map[string]interface{}{"cert": "a.crt", "key": ""}) {
+		t.Errorf("expected values to be mapped as Map does, got %v", values)
+	}
+	expected := map[string]string{
+		"name":     "Name of the service",
+		"port":     "Port to listen on",
+		"tLS":      "TLS settings",
+		"tLS.cert": "Path to the certificate",
+	}
+	if !reflect.DeepEqual(meta, expected) {
+		t.Errorf("expected %v, got %v", expected, meta)
+	}
+}
+
+func TestMapWithMetaNotStruct(t *testing.T) {
+	if _, _, err := mergo.MapWithMeta(map[string]string{}); err != mergo.ErrNotSupported {
+		t.Errorf("expected %v, got %v", mergo.ErrNotSupported, err)
+	}
+}
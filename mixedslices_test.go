@@ -0,0 +1,53 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type mixedItems struct {
+	Items []interface{}
+}
+
+func TestAppendMixedInterfaceSlices(t *testing.T) {
+	dst := mixedItems{Items: []interface{}{true}}
+	src := mixedItems{Items: []interface{}{1, "a", map[string]interface{}{"k": "v"}}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithAppendSlice); err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{true, 1, "a", map[string]interface{}{"k": "v"}}
+	if !reflect.DeepEqual(dst.Items, expected) {
+		t.Errorf("expected %v, got %v", expected, dst.Items)
+	}
+	dst.Items[3].(map[string]interface{})["k"] = "changed"
+	if src.Items[2].(map[string]interface{})["k"] != "v" {
+		t.Error("expected appended elements to be copies of src ones")
+	}
+}
+
+func TestAppendMixedInterfaceSlicesInMaps(t *testing.T) {
+	dst := map[string]interface{}{"items": []interface{}{true}}
+	src := map[string]interface{}{"items": []int{1, 2}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithAppendSlice); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []interface{}{true, 1, 2}; !reflect.DeepEqual(dst["items"], expected) {
+		t.Errorf("expected %v, got %v", expected, dst["items"])
+	}
+}
+
+func TestDeepCopyMixedInterfaceSlices(t *testing.T) {
+	dst := mixedItems{Items: []interface{}{true, map[string]interface{}{"k": "v"}}}
+	src := mixedItems{Items: []interface{}{1, "a"}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepCopy); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []interface{}{true, map[string]interface{}{"k": "v"}}; !reflect.DeepEqual(dst.Items, expected) {
+		t.Errorf("expected mismatching elements to be kept, got %v", dst.Items)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+// mostComplete returns whichever of dst and src, non-empty structs of the same
+// type or pointers to them, has the most non-empty fields, or dst on ties. It
+// reports false if WithMostCompleteWins isn't used or they can't be compared.
+func mostComplete(dst, src reflect.Value, config *Config) (reflect.Value, bool) {
+	if !config.mostCompleteWins || !dst.IsValid() || !src.IsValid() || dst.Type() != src.Type() {
+		return reflect.Value{}, false
+	}
+	dstStruct, srcStruct := reflect.Indirect(dst), reflect.Indirect(src)
+	if dstStruct.Kind() != reflect.Struct || !srcStruct.IsValid() || isEmptyStruct(dstStruct) || isEmptyStruct(srcStruct) {
+		return reflect.Value{}, false
+	}
+	if countNonEmpty(srcStruct, make(map[uintptr]bool)) > countNonEmpty(dstStruct, make(map[uintptr]bool)) {
+		return src, true
+	}
+	return dst, true
+}
+
+// countNonEmpty returns the number of non-empty values held by v, looking into
+// structs, pointers and interfaces. Pointers in seen aren't followed again.
+func countNonEmpty(v reflect.Value, seen map[uintptr]bool) int {
+	switch v.Kind() {
+	case reflect.Struct:
+		count := 0
+		for i, n := 0, v.NumField(); i < n; i++ {
+			count += countNonEmpty(v.Field(i), seen)
+		}
+		return count
+	case reflect.Ptr:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		return countNonEmpty(v.Elem(), seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return countNonEmpty(v.Elem(), seen)
+	}
+	if isEmptyValue(v) {
+		return 0
+	}
+	return 1
+}
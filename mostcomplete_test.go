@@ -0,0 +1,72 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type contactAddress struct {
+	Street string
+	City   string
+}
+
+type contact struct {
+	Name    string
+	Email   string
+	Phone   string
+	Address contactAddress
+}
+
+type addressBook struct {
+	Owner    interface{}
+	Contacts []contact
+}
+
+func TestMostCompleteWinsInterface(t *testing.T) {
+	partial := contact{Name: "Ada", Address: contactAddress{City: "London"}}
+	full := contact{Name: "Ada L.", Email: "ada@example.com", Address: contactAddress{Street: "St James's Sq", City: "London"}}
+
+	dst := addressBook{Owner: partial}
+	if err := mergo.Merge(&dst, addressBook{Owner: full}, mergo.WithMostCompleteWins); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst.Owner, full) {
+		t.Errorf("expected the most complete owner %v, got %v", full, dst.Owner)
+	}
+
+	dst = addressBook{Owner: full}
+	if err := mergo.Merge(&dst, addressBook{Owner: partial}, mergo.WithMostCompleteWins, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst.Owner, full) {
+		t.Errorf("expected the most complete owner to be kept, got %v", dst.Owner)
+	}
+}
+
+func TestMostCompleteWinsSliceElements(t *testing.T) {
+	dst := addressBook{Contacts: []contact{
+		{Name: "Ada", Email: "ada@example.com"},
+		{Name: "Grace", Email: "grace@example.com", Phone: "555"},
+		{Name: "Alan"},
+	}}
+	src := addressBook{Contacts: []contact{
+		{Name: "Ada", Email: "ada@example.com", Phone: "123"},
+		{Name: "Grace H."},
+		{Name: "Turing"},
+	}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepCopy, mergo.WithMostCompleteWins); err != nil {
+		t.Fatal(err)
+	}
+	expected := []contact{
+		{Name: "Ada", Email: "ada@example.com", Phone: "123"},
+		{Name: "Grace", Email: "grace@example.com", Phone: "555"},
+		// Ties keep dst.
+		{Name: "Alan"},
+	}
+	if !reflect.DeepEqual(dst.Contacts, expected) {
+		t.Errorf("expected %v, got %v", expected, dst.Contacts)
+	}
+}
@@ -0,0 +1,53 @@
+package mergo_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type namedLabels map[string]string
+
+type namedValues map[string]interface{}
+
+type labeledService struct {
+	Name   string
+	Labels namedLabels
+}
+
+func TestMergeNamedMapFields(t *testing.T) {
+	dst := labeledService{Labels: namedLabels{"app": "dst"}}
+	src := labeledService{Name: "src", Labels: namedLabels{"app": "src", "tier": "web"}}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (namedLabels{"app": "dst", "tier": "web"}); !reflect.DeepEqual(dst.Labels, expected) {
+		t.Errorf("expected %v, got %v", expected, dst.Labels)
+	}
+}
+
+func TestMapNamedMaps(t *testing.T) {
+	var dst labeledService
+	src := namedValues{"name": "src", "labels": namedLabels{"app": "src"}}
+
+	if err := mergo.Map(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "src" || !reflect.DeepEqual(dst.Labels, namedLabels{"app": "src"}) {
+		t.Errorf("expected the named maps to bind, got %+v", dst)
+	}
+
+	if err := mergo.Map(&dst, namedLabels{"name": "labels"}, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "labels" {
+		t.Errorf("expected a map of strings to bind, got %+v", dst)
+	}
+
+	if err := mergo.Map(&dst, map[int]string{1: "x"}); !errors.Is(err, mergo.ErrNotSupported) {
+		t.Errorf("expected %v for int keys, got %v", mergo.ErrNotSupported, err)
+	}
+}
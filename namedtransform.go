@@ -0,0 +1,56 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// transformedField returns the field i of the struct src passed through the
+// transform named by its `mergo:"transform=name"` tag, see WithNamedTransforms.
+func transformedField(src reflect.Value, i int, config *Config) (reflect.Value, error) {
+	value := src.Field(i)
+	if config.namedTransforms == nil {
+		return value, nil
+	}
+	field := &structFieldsOf(src.Type())[i]
+	if !field.hasTransform {
+		return value, nil
+	}
+	name := field.transform
+	transform, ok := config.namedTransforms[name]
+	if !ok {
+		return value, fmt.Errorf("unknown transform %q for %s field", name, field.Name)
+	}
+	transformed := transform(value)
+	if !transformed.IsValid() {
+		return value, fmt.Errorf("%w: transform %q returned no value for %s field", ErrInvalidValue, name, field.Name)
+	}
+	if transformed.Type() != value.Type() {
+		return value, typeMismatch(config, fmt.Errorf("transform %q returned a different type for %s field", name, field.Name), value.Type(), transformed.Type())
+	}
+	return transformed, nil
+}
+
+func transformName(field reflect.StructField) (string, bool) {
+	return tagOption(field, "transform=")
+}
+
+// tagOption returns the value of the option of the mergo tag of field starting
+// with prefix, like the name in `mergo:"transform=name"`.
+func tagOption(field reflect.StructField, prefix string) (string, bool) {
+	tag, ok := field.Tag.Lookup("mergo")
+	if !ok {
+		return "", false
+	}
+	for _, option := range strings.Split(tag, ",") {
+		if value := strings.TrimPrefix(option, prefix); value != option {
+			return value, true
+		}
+	}
+	return "", false
+}
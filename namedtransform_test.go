@@ -0,0 +1,52 @@
+package mergo_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type transformedCountry struct {
+	Code string `mergo:"transform=uppercase"`
+	Name string
+}
+
+var namedTransforms = map[string]func(reflect.Value) reflect.Value{
+	"uppercase": func(v reflect.Value) reflect.Value {
+		return reflect.ValueOf(strings.ToUpper(v.String()))
+	},
+}
+
+func TestNamedTransforms(t *testing.T) {
+	var dst transformedCountry
+	src := transformedCountry{Code: "es", Name: "Spain"}
+
+	if err := mergo.Merge(&dst, src, mergo.WithNamedTransforms(namedTransforms)); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (transformedCountry{Code: "ES", Name: "Spain"}); dst != expected {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+	if src.Code != "es" {
+		t.Errorf("expected src to be untouched, got %+v", src)
+	}
+
+	dst = transformedCountry{}
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Code != "es" {
+		t.Errorf("expected no transform without the option, got %+v", dst)
+	}
+}
+
+func TestNamedTransformsUnknown(t *testing.T) {
+	var dst transformedCountry
+	transforms := mergo.WithNamedTransforms(map[string]func(reflect.Value) reflect.Value{})
+
+	if err := mergo.Merge(&dst, transformedCountry{Code: "es"}, transforms); err == nil {
+		t.Error("expected an error for the unknown transform")
+	}
+}
@@ -0,0 +1,39 @@
+package mergo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type normalizedConfig struct {
+	Café  string
+	Crème string `mapstructure:"crème_brûlée"`
+}
+
+// composeAccents stands for norm.NFC.String, composing the few decomposed
+// letters used below.
+var composeAccents = strings.NewReplacer("é", "é", "è", "è", "û", "û").Replace
+
+func TestUnicodeNormalization(t *testing.T) {
+	for _, key := range []string{"café", "café"} {
+		var dst normalizedConfig
+		src := map[string]interface{}{key: "au lait", "crème_brûlée": "vanilla"}
+
+		if err := mergo.Map(&dst, src, mergo.WithUnicodeNormalization(composeAccents), mergo.WithTagName("mapstructure")); err != nil {
+			t.Fatal(err)
+		}
+		if dst.Café != "au lait" || dst.Crème != "vanilla" {
+			t.Errorf("expected %q keys to bind, got %+v", key, dst)
+		}
+	}
+
+	var dst normalizedConfig
+	if err := mergo.Map(&dst, map[string]interface{}{"café": "au lait"}); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Café != "" {
+		t.Errorf("expected the decomposed key not to bind without normalization, got %+v", dst)
+	}
+}
@@ -0,0 +1,77 @@
+package mergo_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type casedConfig struct {
+	Host string
+	Port int
+}
+
+func TestNormalizeKeysMap(t *testing.T) {
+	dst := casedConfig{}
+	src := map[string]interface{}{"HOST": "example.com", "Port": 8080}
+
+	if err := mergo.Map(&dst, src, mergo.WithNormalizeKeys(strings.ToLower)); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (casedConfig{Host: "example.com", Port: 8080}); dst != expected {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestNormalizeKeysMerge(t *testing.T) {
+	dst := map[string]interface{}{"host": "localhost", "labels": map[string]string{"env": "dev"}}
+	src := map[string]interface{}{"HOST": "example.com", "Labels": map[string]string{"Env": "prod", "Tier": "web"}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithNormalizeKeys(strings.ToLower)); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"host": "example.com", "labels": map[string]string{"env": "prod", "tier": "web"}}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestNormalizeKeysCollision(t *testing.T) {
+	dst := casedConfig{}
+	src := map[string]interface{}{"host": "a", "HOST": "b"}
+
+	err := mergo.Map(&dst, src, mergo.WithNormalizeKeys(strings.ToLower))
+	if !errors.Is(err, mergo.ErrKeyCollision) {
+		t.Errorf("expected %v, got %v", mergo.ErrKeyCollision, err)
+	}
+}
+
+func TestNormalizeKeysRequiredFields(t *testing.T) {
+	dst := casedConfig{}
+	src := map[string]interface{}{"HOST": "example.com", "PORT": 8080}
+
+	if err := mergo.Map(&dst, src, mergo.WithNormalizeKeys(strings.ToLower), mergo.WithRequiredFields([]string{"Host", "Port"})); err != nil {
+		t.Fatalf("expected the rewritten keys to count for their fields, got %v", err)
+	}
+	if expected := (casedConfig{Host: "example.com", Port: 8080}); dst != expected {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestNormalizeKeysWithUnicodeNormalization(t *testing.T) {
+	// The key is lowered by WithNormalizeKeys first, so composeAccents, which
+	// only composes lowercase letters, can match it to Café.
+	src := map[string]interface{}{"CAFE\u0301": "au lait"}
+	var dst normalizedConfig
+
+	err := mergo.Map(&dst, src, mergo.WithNormalizeKeys(strings.ToLower), mergo.WithUnicodeNormalization(composeAccents), mergo.WithRequiredFields([]string{"Café"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst.Café != "au lait" {
+		t.Errorf("expected the key to bind Café, got %+v", dst)
+	}
+}
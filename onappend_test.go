@@ -0,0 +1,34 @@
+package mergo_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type appendedLayer struct {
+	Hosts  []string
+	Extras map[string]interface{}
+}
+
+func TestOnAppend(t *testing.T) {
+	var appended []string
+	onAppend := mergo.WithOnAppend(func(path string, element reflect.Value) {
+		appended = append(appended, fmt.Sprintf("%s=%v", path, element.Interface()))
+	})
+	dst := appendedLayer{Hosts: []string{"a"}, Extras: map[string]interface{}{"ports": []interface{}{80}}}
+	for _, layer := range []appendedLayer{
+		{Hosts: []string{"b", "c"}},
+		{Hosts: []string{"d"}, Extras: map[string]interface{}{"ports": []interface{}{443}}},
+	} {
+		if err := mergo.Merge(&dst, layer, mergo.WithAppendSlice, onAppend); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := []string{"Hosts[1]=b", "Hosts[2]=c", "Hosts[3]=d", "Extras[ports][1]=443"}
+	if !reflect.DeepEqual(appended, expected) {
+		t.Errorf("expected %v, got %v", expected, appended)
+	}
+}
@@ -0,0 +1,48 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type EmbeddedMetadata struct {
+	Owner string
+	Tags  []string
+}
+
+type EmbeddedStatus struct {
+	Phase string
+}
+
+type composedResource struct {
+	EmbeddedMetadata
+	*EmbeddedStatus
+	Name string
+}
+
+func TestOnlyEmbedded(t *testing.T) {
+	dst := composedResource{Name: "dst", EmbeddedStatus: &EmbeddedStatus{}}
+	src := composedResource{
+		EmbeddedMetadata: EmbeddedMetadata{Owner: "src", Tags: []string{"src"}},
+		EmbeddedStatus:   &EmbeddedStatus{Phase: "running"},
+		Name:             "src",
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithOnlyEmbedded("EmbeddedStatus")); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Phase != "running" {
+		t.Errorf("expected the status to be merged, got %+v", dst.EmbeddedStatus)
+	}
+	if dst.Name != "dst" || dst.Owner != "" {
+		t.Errorf("expected the other fields to be skipped, got %+v", dst)
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOnlyEmbedded("mergo_test.EmbeddedMetadata")); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Owner != "src" || dst.Name != "dst" {
+		t.Errorf("expected the metadata to be merged by its qualified name, got %+v", dst)
+	}
+}
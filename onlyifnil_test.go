@@ -0,0 +1,58 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type referenceFields struct {
+	Hosts  []string
+	Labels map[string]string
+	TLS    *layeredTLS
+	Name   string
+}
+
+func newReferenceSource() referenceFields {
+	return referenceFields{
+		Hosts:  []string{"a"},
+		Labels: map[string]string{"env": "dev"},
+		TLS:    &layeredTLS{Cert: "src.crt"},
+		Name:   "src",
+	}
+}
+
+func TestMergeOnlyIfNil(t *testing.T) {
+	dst := referenceFields{Hosts: []string{}, Labels: map[string]string{}, TLS: &layeredTLS{}}
+
+	if err := mergo.Merge(&dst, newReferenceSource(), mergo.WithMergeOnlyIfNil); err != nil {
+		t.Fatal(err)
+	}
+	expected := referenceFields{Hosts: []string{}, Labels: map[string]string{}, TLS: &layeredTLS{}, Name: "src"}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected initialized fields to be preserved, got %+v", dst)
+	}
+}
+
+func TestMergeOnlyIfNilFillsNil(t *testing.T) {
+	dst := referenceFields{}
+
+	if err := mergo.Merge(&dst, newReferenceSource(), mergo.WithMergeOnlyIfNil); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst, newReferenceSource()) {
+		t.Errorf("expected nil fields to be merged, got %+v", dst)
+	}
+}
+
+func TestMergeOnlyIfNilDisabled(t *testing.T) {
+	dst := referenceFields{Hosts: []string{}, Labels: map[string]string{}}
+
+	if err := mergo.Merge(&dst, newReferenceSource()); err != nil {
+		t.Fatal(err)
+	}
+	if len(dst.Hosts) != 1 || len(dst.Labels) != 1 {
+		t.Errorf("expected empty fields to be filled without the option, got %+v", dst)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+// Optional is a value that may be unset, to express optional fields of patches
+// without pointers. Merging an unset Optional is a no-op, while a set one is
+// always applied, even if its Value is empty. MergeOptional applies the Value
+// of a set Optional[T] to a dst field of type T.
+type Optional[T any] struct {
+	Set   bool
+	Value T
+}
+
+// Some returns an Optional set to v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Set: true, Value: v}
+}
+
+func (o Optional[T]) optionalValue() (interface{}, bool) {
+	return o.Value, o.Set
+}
+
+// optional is implemented by every Optional type, which can't be told apart
+// otherwise through reflection.
+type optional interface {
+	optionalValue() (interface{}, bool)
+}
+
+var optionalType = reflect.TypeOf((*optional)(nil)).Elem()
+
+// optionalValue returns the value held by v if it is a set Optional.
+func optionalValue(v reflect.Value) (value reflect.Value, isOptional bool) {
+	if v.Kind() != reflect.Struct || !v.Type().Implements(optionalType) || !v.CanInterface() {
+		return reflect.Value{}, false
+	}
+	if _, set := v.Interface().(optional).optionalValue(); set {
+		return v.FieldByName("Value"), true
+	}
+	return reflect.Value{}, true
+}
+
+// MergeOptional merges src, a struct (or pointer to struct) of pointer fields,
+// into dst, which must be a pointer to struct. Fields are matched by name and a
+// nil src pointer means "skip", while a non-nil one is dereferenced and applied
+// to the dst field even if it points to an empty value. The dst field may be
+// a value or a pointer of the pointed type. Optional fields work the same
+// way, an unset one being skipped. Pointers to structs whose type is
+// different from the dst field are applied recursively with the same rules.
+// Non-pointer src fields are merged following the given options.
+func MergeOptional(dst, src interface{}, opts ...func(*Config)) error {
+	if dst == nil || src == nil {
+		return ErrNilArguments
+	}
+	vDst := reflect.ValueOf(dst)
+	if vDst.Kind() != reflect.Ptr {
+		return ErrNonPointerAgument
+	}
+	if vDst = vDst.Elem(); vDst.Kind() != reflect.Struct {
+		return ErrExpectedStructAsDestination
+	}
+	vSrc := reflect.Indirect(reflect.ValueOf(src))
+	if vSrc.Kind() != reflect.Struct {
+		return ErrNotSupported
+	}
+	config := &Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return collectErrors(config, mergeOptional(vDst, vSrc, make(map[visit]bool), 0, config))
+}
+
+func mergeOptional(dst, src reflect.Value, visited map[visit]bool, depth int, config *Config) error {
+	srcType := src.Type()
+	for i, n := 0, srcType.NumField(); i < n; i++ {
+		field := srcType.Field(i)
+		if !isExported(field) {
+			continue
+		}
+		dstField := fieldByName(dst, field.Name)
+		if !dstField.IsValid() || !dstField.CanSet() {
+			// We discard it because the field doesn't exist.
+			continue
+		}
+		if isReadOnlyByName(dst.Type(), field.Name) {
+			pushField(config, field.Name)
+			traceDecision(config, TraceProtect, dstField, dstField)
+			popPath(config)
+			continue
+		}
+		pushField(config, field.Name)
+		err := mergeOptionalField(dstField, src.Field(i), visited, depth+1, config)
+		popPath(config)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mergeOptionalField(dst, src reflect.Value, visited map[visit]bool, depth int, config *Config) error {
+	if src.Type() != dst.Type() {
+		if value, ok := optionalValue(src); ok {
+			if !value.IsValid() {
+				return nil
+			}
+			// Take its address to apply it as a present pointer.
+			ptr := reflect.New(value.Type())
+			ptr.Elem().Set(value)
+			src = ptr
+		}
+	}
+	if src.Kind() != reflect.Ptr {
+		if src.Type() != dst.Type() {
+			return ErrDifferentArgumentsTypes
+		}
+		return deepMerge(dst, src, visited, depth, config)
+	}
+	if src.IsNil() {
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+	value := src.Elem()
+	if value.Type() != dst.Type() {
+		if value.Kind() == reflect.Struct && dst.Kind() == reflect.Struct {
+			return mergeOptional(dst, value, visited, depth, config)
+		}
+		return ErrDifferentArgumentsTypes
+	}
+	// Presence is what matters here, so any present value must win.
+	overwrite, overwriteWithEmptyValue := config.Overwrite, config.overwriteWithEmptyValue
+	config.Overwrite, config.overwriteWithEmptyValue = true, true
+	err := deepMerge(dst, value, visited, depth, config)
+	config.Overwrite, config.overwriteWithEmptyValue = overwrite, overwriteWithEmptyValue
+	return err
+}
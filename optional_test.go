@@ -0,0 +1,136 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type optionalLimits struct {
+	CPU    int
+	Memory string
+}
+
+type optionalConfig struct {
+	Name     string
+	Replicas int
+	Debug    bool
+	Timeout  *int
+	Limits   optionalLimits
+	Tags     []string
+}
+
+type optionalLimitsPatch struct {
+	CPU    *int
+	Memory *string
+}
+
+type optionalConfigPatch struct {
+	Name     *string
+	Replicas *int
+	Debug    *bool
+	Timeout  *int
+	Limits   *optionalLimitsPatch
+	Tags     []string
+}
+
+func intPtr(i int) *int          { return &i }
+func stringPtr(s string) *string { return &s }
+func boolPtr(b bool) *bool       { return &b }
+
+func TestMergeOptional(t *testing.T) {
+	dst := optionalConfig{
+		Name:     "api",
+		Replicas: 3,
+		Debug:    true,
+		Limits:   optionalLimits{CPU: 2, Memory: "1Gi"},
+		Tags:     []string{"a"},
+	}
+	src := optionalConfigPatch{
+		Replicas: intPtr(0),
+		Debug:    boolPtr(false),
+		Timeout:  intPtr(30),
+		Limits:   &optionalLimitsPatch{Memory: stringPtr("")},
+		Tags:     []string{"b"},
+	}
+
+	if err := mergo.MergeOptional(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	expected := optionalConfig{
+		Name:    "api",
+		Timeout: intPtr(30),
+		Limits:  optionalLimits{CPU: 2},
+		Tags:    []string{"a"},
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+	if *src.Timeout = 10; *dst.Timeout != 30 {
+		t.Errorf("expected dst not to share pointers with src")
+	}
+}
+
+func TestMergeOptionalWithOptions(t *testing.T) {
+	dst := optionalConfig{Tags: []string{"a"}}
+	src := &optionalConfigPatch{Name: stringPtr("web"), Tags: []string{"b"}}
+
+	if err := mergo.MergeOptional(&dst, src, mergo.WithAppendSlice); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (optionalConfig{Name: "web", Tags: []string{"a", "b"}}); !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+}
+
+func TestMergeOptionalTypeMismatch(t *testing.T) {
+	dst := optionalConfig{}
+	src := struct{ Name *int }{Name: intPtr(1)}
+	if err := mergo.MergeOptional(&dst, src); err != mergo.ErrDifferentArgumentsTypes {
+		t.Errorf("expected %v, got %v", mergo.ErrDifferentArgumentsTypes, err)
+	}
+}
+
+type optionalTypedPatch struct {
+	Name     mergo.Optional[string]
+	Replicas mergo.Optional[int]
+	Debug    mergo.Optional[bool]
+	Timeout  mergo.Optional[int]
+	Limits   mergo.Optional[optionalLimits]
+}
+
+func TestMergeOptionalTyped(t *testing.T) {
+	dst := optionalConfig{Name: "api", Replicas: 3, Debug: true, Limits: optionalLimits{CPU: 2, Memory: "1Gi"}}
+	src := optionalTypedPatch{
+		Replicas: mergo.Some(0),
+		Debug:    mergo.Some(false),
+		Timeout:  mergo.Some(30),
+		Limits:   mergo.Some(optionalLimits{CPU: 4}),
+	}
+
+	if err := mergo.MergeOptional(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	expected := optionalConfig{Name: "api", Timeout: intPtr(30), Limits: optionalLimits{CPU: 4}}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+}
+
+type optionalFields struct {
+	Name mergo.Optional[string]
+	Port mergo.Optional[int]
+}
+
+func TestMergeOptionalFields(t *testing.T) {
+	dst := optionalFields{Name: mergo.Some("dst"), Port: mergo.Some(80)}
+	src := optionalFields{Port: mergo.Some(0)}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (optionalFields{Name: mergo.Some("dst"), Port: mergo.Some(0)}); dst != expected {
+		t.Errorf("expected unset fields to be skipped and set ones applied, got %+v", dst)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// stringKeys returns the keys of m, sorted if WithDeterministicOrder is used.
+func stringKeys(m map[string]interface{}, config *Config) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	if config.deterministicOrder {
+		sort.Strings(keys)
+	}
+	return keys
+}
+
+// mapKeys returns the keys of the map v, sorted if WithDeterministicOrder is
+// used so anything depending on the iteration order is reproducible.
+func mapKeys(v reflect.Value, config *Config) []reflect.Value {
+	keys := v.MapKeys()
+	if config.deterministicOrder {
+		sort.Slice(keys, func(i, j int) bool {
+			return lessKey(keys[i], keys[j])
+		})
+	}
+	return keys
+}
+
+// lessKey orders map keys by their value when they are strings, numbers or
+// booleans, and by their formatted value otherwise.
+func lessKey(a, b reflect.Value) bool {
+	a, b = unwrapInterface(a), unwrapInterface(b)
+	switch {
+	case a.Kind() != b.Kind():
+		return a.Kind() < b.Kind()
+	case a.Kind() == reflect.String:
+		return a.String() < b.String()
+	case isIntKind(a.Kind()):
+		return a.Int() < b.Int()
+	case isUintKind(a.Kind()):
+		return a.Uint() < b.Uint()
+	case isFloatKind(a.Kind()):
+		return a.Float() < b.Float()
+	case a.Kind() == reflect.Bool:
+		return !a.Bool() && b.Bool()
+	}
+	return formatKey(a) < formatKey(b)
+}
+
+func formatKey(v reflect.Value) string {
+	if v.CanInterface() {
+		return fmt.Sprint(v.Interface())
+	}
+	return v.String()
+}
@@ -0,0 +1,70 @@
+package mergo_test
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type recordingTransformer struct {
+	calls []string
+}
+
+func (r *recordingTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ.Kind() != reflect.String {
+		return nil
+	}
+	return func(dst, src reflect.Value) error {
+		r.calls = append(r.calls, src.String())
+		dst.Set(src)
+		return nil
+	}
+}
+
+func TestDeterministicOrderTransformers(t *testing.T) {
+	src := map[string]interface{}{}
+	for _, key := range strings.Split("jihgfedcba", "") {
+		src[key] = key
+	}
+	expected := strings.Split("abcdefghij", "")
+	for i := 0; i < 10; i++ {
+		recorder := &recordingTransformer{}
+		dst := map[string]interface{}{}
+		if err := mergo.Merge(&dst, src, mergo.WithTransformers(recorder), mergo.WithDeterministicOrder); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(recorder.calls, expected) {
+			t.Fatalf("expected transformers to be called in order %v, got %v", expected, recorder.calls)
+		}
+	}
+}
+
+func TestDeterministicOrderMapErrors(t *testing.T) {
+	src := map[string]interface{}{"port": "x", "count": "y", "name": 1}
+	for i := 0; i < 10; i++ {
+		dst := struct {
+			Name  string
+			Count int
+			Port  int
+		}{}
+		err := mergo.Map(&dst, src, mergo.WithDeterministicOrder)
+		if err == nil || !strings.Contains(err.Error(), "Count") {
+			t.Fatalf("expected the first error to be on Count, got %v", err)
+		}
+	}
+}
+
+func TestDeterministicOrderKeys(t *testing.T) {
+	recorder := &recordingTransformer{}
+	dst := map[int]string{}
+	src := map[int]string{3: "c", -1: "a", 2: "b"}
+	if err := mergo.Merge(&dst, src, mergo.WithTransformers(recorder), mergo.WithDeterministicOrder); err != nil {
+		t.Fatal(err)
+	}
+	if !sort.StringsAreSorted(recorder.calls) || len(recorder.calls) != 3 {
+		t.Errorf("expected int keys to be visited in numeric order, got %v", recorder.calls)
+	}
+}
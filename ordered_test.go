@@ -0,0 +1,99 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type orderedConfig struct {
+	Timeout int
+	Name    string
+	hidden  string
+	Port    int
+	Host    string
+}
+
+func orderedKeys(entries []mergo.KeyValue) []string {
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		keys = append(keys, entry.Key)
+	}
+	return keys
+}
+
+func TestMapOrderedDeclarationOrder(t *testing.T) {
+	src := orderedConfig{Timeout: 5, Name: "svc", hidden: "x", Port: 80, Host: "localhost"}
+	entries, err := mergo.MapOrdered(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []mergo.KeyValue{
+		{Key: "timeout", Value: 5},
+		{Key: "name", Value: "svc"},
+		{Key: "port", Value: 80},
+		{Key: "host", Value: "localhost"},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("expected %v, got %v", expected, entries)
+	}
+}
+
+func TestMapOrderedWithKeyOrder(t *testing.T) {
+	src := &orderedConfig{Timeout: 5, Name: "svc", Port: 80, Host: "localhost"}
+	entries, err := mergo.MapOrdered(src, mergo.WithKeyOrder([]string{"host", "port", "missing", "host"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"host", "port", "timeout", "name"}
+	if keys := orderedKeys(entries); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+	if entries[0].Value != "localhost" || entries[1].Value != 80 {
+		t.Errorf("values don't follow their keys: %v", entries)
+	}
+}
+
+func TestMapOrderedNotStruct(t *testing.T) {
+	if _, err := mergo.MapOrdered(map[string]interface{}{}); err != mergo.ErrNotSupported {
+		t.Errorf("expected %v, got %v", mergo.ErrNotSupported, err)
+	}
+}
+
+type orderedGetters struct {
+	Name string
+	P    *int
+}
+
+func (o orderedGetters) Label() string {
+	return "label:" + o.Name
+}
+
+func (o orderedGetters) Index() int {
+	return 1
+}
+
+func TestMapOrderedSameKeysAsMap(t *testing.T) {
+	src := orderedGetters{Name: "svc"}
+	entries, err := mergo.MapOrdered(src, mergo.WithIncludeGetters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapped := map[string]interface{}{}
+	if err := mergo.Map(&mapped, src, mergo.WithIncludeGetters); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(mapped) {
+		t.Errorf("expected the keys of Map, %v, got %v", mapped, entries)
+	}
+	for _, entry := range entries {
+		if value, ok := mapped[entry.Key]; !ok || !reflect.DeepEqual(value, entry.Value) {
+			t.Errorf("expected %s to be mapped as %v, got %v", entry.Key, value, entry.Value)
+		}
+	}
+	expected := []string{"name", "index", "label"}
+	if keys := orderedKeys(entries); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+}
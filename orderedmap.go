@@ -0,0 +1,82 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+// OrderedMap is implemented by insertion-ordered maps, like the ones of YAML
+// libraries, through a pointer or not. merge sets the keys of a src one not
+// found in dst after the dst ones, in src order, and merges the values of the
+// keys found in both with the usual rules.
+type OrderedMap interface {
+	Keys() []interface{}
+	Get(key interface{}) (interface{}, bool)
+	Set(key, value interface{})
+}
+
+var orderedMapType = reflect.TypeOf((*OrderedMap)(nil)).Elem()
+
+// orderedMapOf returns v as an OrderedMap, taking its address if needed.
+func orderedMapOf(v reflect.Value) (OrderedMap, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+	if v.Type().Implements(orderedMapType) && !isReflectNil(v) {
+		return v.Interface().(OrderedMap), true
+	}
+	if reflect.PtrTo(v.Type()).Implements(orderedMapType) {
+		if !v.CanAddr() {
+			ptr := reflect.New(v.Type())
+			ptr.Elem().Set(v)
+			v = ptr.Elem()
+		}
+		return v.Addr().Interface().(OrderedMap), true
+	}
+	return nil, false
+}
+
+// deepMergeOrderedMap merges src into dst if both are OrderedMaps of the same
+// type. It reports whether they were.
+func deepMergeOrderedMap(dst, src reflect.Value, visited map[visit]bool, depth int, config *Config) (bool, error) {
+	if dst.Type() != src.Type() || (dst.Kind() != reflect.Ptr && !dst.CanAddr()) {
+		return false, nil
+	}
+	dstMap, ok := orderedMapOf(dst)
+	if !ok {
+		return false, nil
+	}
+	srcMap, ok := orderedMapOf(src)
+	if !ok {
+		return true, nil
+	}
+	pushKey(config, reflect.Value{})
+	defer popPath(config)
+	for _, key := range srcMap.Keys() {
+		config.path[len(config.path)-1] = pathElement{key: reflect.ValueOf(key)}
+		if err := countField(config); err != nil {
+			return true, err
+		}
+		srcValue, _ := srcMap.Get(key)
+		dstValue, found := dstMap.Get(key)
+		if !found || dstValue == nil {
+			dstMap.Set(key, srcValue)
+			continue
+		}
+		if srcValue == nil || reflect.TypeOf(dstValue) != reflect.TypeOf(srcValue) {
+			if config.Overwrite && srcValue != nil {
+				dstMap.Set(key, srcValue)
+			}
+			continue
+		}
+		// Values held by interfaces aren't addressable, so merge a copy.
+		merged := reflect.New(reflect.TypeOf(dstValue)).Elem()
+		merged.Set(reflect.ValueOf(dstValue))
+		if err := deepMerge(merged, reflect.ValueOf(srcValue), visited, depth+1, config); err != nil {
+			return true, err
+		}
+		dstMap.Set(key, merged.Interface())
+	}
+	return true, nil
+}
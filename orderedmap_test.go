@@ -0,0 +1,98 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+// orderedMap is a minimal insertion-ordered map, like the ones of YAML
+// libraries.
+type orderedMap struct {
+	keys   []interface{}
+	values map[interface{}]interface{}
+}
+
+func newOrderedMap(kv ...interface{}) *orderedMap {
+	m := &orderedMap{}
+	for i := 0; i < len(kv); i += 2 {
+		m.Set(kv[i], kv[i+1])
+	}
+	return m
+}
+
+func (m *orderedMap) Keys() []interface{} {
+	return m.keys
+}
+
+func (m *orderedMap) Get(key interface{}) (interface{}, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+func (m *orderedMap) Set(key, value interface{}) {
+	if m.values == nil {
+		m.values = make(map[interface{}]interface{})
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+type orderedDocument struct {
+	Name   string
+	Values *orderedMap
+	Inline orderedMap
+}
+
+func TestMergeOrderedMaps(t *testing.T) {
+	dst := orderedDocument{
+		Values: newOrderedMap("b", 1, "a", 0, "nested", newOrderedMap("x", 1)),
+		Inline: *newOrderedMap("z", "dst"),
+	}
+	src := orderedDocument{
+		Name:   "src",
+		Values: newOrderedMap("d", 4, "a", 2, "nested", newOrderedMap("y", 2, "x", 3), "c", 3),
+		Inline: *newOrderedMap("y", "src", "z", "src"),
+	}
+
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []interface{}{"b", "a", "nested", "d", "c"}; !reflect.DeepEqual(dst.Values.Keys(), expected) {
+		t.Errorf("expected keys %v, got %v", expected, dst.Values.Keys())
+	}
+	if a, _ := dst.Values.Get("a"); a != 2 {
+		t.Errorf("expected the empty value to be filled, got %v", a)
+	}
+	if b, _ := dst.Values.Get("b"); b != 1 {
+		t.Errorf("expected b to be kept, got %v", b)
+	}
+	nested, _ := dst.Values.Get("nested")
+	if keys := nested.(*orderedMap).Keys(); !reflect.DeepEqual(keys, []interface{}{"x", "y"}) {
+		t.Errorf("expected nested maps to be merged in order, got %v", keys)
+	}
+	if x, _ := nested.(*orderedMap).Get("x"); x != 1 {
+		t.Errorf("expected nested x to be kept, got %v", x)
+	}
+	if keys := dst.Inline.Keys(); !reflect.DeepEqual(keys, []interface{}{"z", "y"}) {
+		t.Errorf("expected inline keys in order, got %v", keys)
+	}
+	if z, _ := dst.Inline.Get("z"); z != "dst" {
+		t.Errorf("expected inline z to be kept, got %v", z)
+	}
+}
+
+func TestMergeOrderedMapsOverride(t *testing.T) {
+	dst := newOrderedMap("a", 1, "b", 2)
+	src := newOrderedMap("b", 3, "a", 4)
+
+	if err := mergo.Merge(&orderedDocument{Values: dst}, orderedDocument{Values: src}, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if a, _ := dst.Get("a"); a != 4 || !reflect.DeepEqual(dst.Keys(), []interface{}{"a", "b"}) {
+		t.Errorf("expected values to be overridden keeping the dst order, got %v", dst.values)
+	}
+}
@@ -0,0 +1,52 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/imdario/mergo"
+)
+
+type auditedConfig struct {
+	Name      string
+	Port      int
+	UpdatedAt time.Time
+	Nested    struct {
+		Owner     string
+		CheckedAt time.Time
+	}
+}
+
+func TestOverrideTypes(t *testing.T) {
+	before := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	after := before.AddDate(1, 0, 0)
+	dst := auditedConfig{Name: "dst", UpdatedAt: before}
+	dst.Nested.Owner = "dst"
+	dst.Nested.CheckedAt = before
+	src := auditedConfig{Name: "src", Port: 80, UpdatedAt: after}
+	src.Nested.Owner = "src"
+	src.Nested.CheckedAt = after
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverrideTypes(reflect.TypeOf(time.Time{}))); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "dst" || dst.Nested.Owner != "dst" || dst.Port != 80 {
+		t.Errorf("expected other fields to be only filled, got %+v", dst)
+	}
+	if !dst.UpdatedAt.Equal(after) || !dst.Nested.CheckedAt.Equal(after) {
+		t.Errorf("expected time.Time fields to be overridden, got %+v", dst)
+	}
+}
+
+func TestOverrideTypesMapValues(t *testing.T) {
+	dst := map[string]interface{}{"name": "dst", "port": 80}
+	src := map[string]interface{}{"name": "src", "port": 8080}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithOverrideTypes(reflect.TypeOf(0))); err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]interface{}{"name": "dst", "port": 8080}; !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected only ints to be overridden, got %v", dst)
+	}
+}
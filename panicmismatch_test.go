@@ -0,0 +1,55 @@
+package mergo_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func recoverMessage(f func() error) (msg string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = fmt.Sprint(r)
+		}
+	}()
+	return "", f()
+}
+
+func TestPanicOnTypeMismatch(t *testing.T) {
+	dst := struct{ Port int }{}
+	src := map[string]interface{}{"port": "80"}
+
+	msg, err := recoverMessage(func() error {
+		return mergo.Map(&dst, src, mergo.WithPanicOnTypeMismatch)
+	})
+	if err != nil {
+		t.Fatalf("expected a panic, got error %v", err)
+	}
+	if !strings.Contains(msg, `at "Port"`) || !strings.Contains(msg, "dst: int, src: string") {
+		t.Errorf("expected the panic to tell the path and types, got %q", msg)
+	}
+}
+
+func TestPanicOnTypeMismatchSlices(t *testing.T) {
+	dst := map[string]interface{}{"hosts": []string{"a"}}
+	src := map[string]interface{}{"hosts": []int{1}}
+
+	msg, _ := recoverMessage(func() error {
+		return mergo.Merge(&dst, src, mergo.WithAppendSlice, mergo.WithPanicOnTypeMismatch)
+	})
+	if !strings.Contains(msg, "cannot append two slices with different type") || !strings.Contains(msg, `at "[hosts]"`) {
+		t.Errorf("expected a panic on the slices, got %q", msg)
+	}
+}
+
+func TestTypeMismatchErrorByDefault(t *testing.T) {
+	dst := struct{ Port int }{}
+	msg, err := recoverMessage(func() error {
+		return mergo.Map(&dst, map[string]interface{}{"port": "80"})
+	})
+	if msg != "" || err == nil {
+		t.Errorf("expected an error and no panic, got %v and %q", err, msg)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"reflect"
+	"sync"
+)
+
+// deepMergeFieldsConcurrently merges the fields of the struct dst like deepMerge
+// does, but spread over config.parallelism goroutines, see WithParallelism.
+// Each field is merged with its own copy of config and visited map, which are
+// joined back in field order once all of them are done.
+func deepMergeFieldsConcurrently(dst, src reflect.Value, depth int, config *Config) error {
+	n := dst.NumField()
+	forks := make([]*Config, n)
+	for i := 0; i < n; i++ {
+		if err := countField(config); err != nil {
+			return err
+		}
+		if config.ignoreUnexportedTypes && isUnexportedType(dst.Type().Field(i).Type) {
+			continue
+		}
+		if config.mergeOnlyIfNil && isSetReference(dst.Field(i)) {
+			continue
+		}
+		if protectField(dst, i, config) {
+			continue
+		}
+		if config.onlyEmbedded != nil && depth == 0 && !isOnlyEmbedded(dst.Type().Field(i), config) {
+			continue
+		}
+		forks[i] = forkConfig(config)
+	}
+	errs := make([]error, n)
+	fields := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < config.parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range fields {
+				fork := forks[i]
+				pushField(fork, dst.Type().Field(i).Name)
+				errs[i] = deepMergeField(dst, src, i, make(map[visit]bool), depth, fork)
+				popPath(fork)
+			}
+		}()
+	}
+	for i, fork := range forks {
+		if fork != nil {
+			fields <- i
+		}
+	}
+	close(fields)
+	wg.Wait()
+	for i, fork := range forks {
+		if fork == nil {
+			continue
+		}
+		config.errors = append(config.errors, fork.errors...)
+		if config.trace != nil {
+			*config.trace = append(*config.trace, *fork.trace...)
+		}
+		if err := errs[i]; err != nil {
+			return err
+		}
+		// Forks count their fields and bytes apart, so the limits are
+		// checked for all.
+		if config.fieldCount += fork.fieldCount; config.maxFields > 0 && config.fieldCount > config.maxFields {
+			return ErrTooManyFields
+		}
+		if err := chargeBytes(config, fork.copiedBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forkConfig returns a copy of config with its own per-call state, starting at
+// the same path.
+func forkConfig(config *Config) *Config {
+	fork := *config
+	fork.path = append([]pathElement(nil), config.path...)
+	fork.fieldCount, fork.copiedBytes, fork.errors, fork.mapQueue = 0, 0, nil, nil
+	if config.trace != nil {
+		fork.trace = new([]TraceEntry)
+	}
+	return &fork
+}
@@ -0,0 +1,96 @@
+package mergo_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type shard struct {
+	Name    string
+	Weights map[string]int
+	Hosts   []string
+}
+
+type shardedConfig struct {
+	A, B, C, D, E, F, G, H shard
+	Total                  int
+}
+
+func newShardedConfig(size int) shardedConfig {
+	var config shardedConfig
+	v := reflect.ValueOf(&config).Elem()
+	for i := 0; i < v.NumField()-1; i++ {
+		s := shard{Name: fmt.Sprint("shard", i), Weights: make(map[string]int, size)}
+		for j := 0; j < size; j++ {
+			s.Weights[fmt.Sprint("key", j)] = j
+			s.Hosts = append(s.Hosts, fmt.Sprint("host", j))
+		}
+		v.Field(i).Set(reflect.ValueOf(s))
+	}
+	config.Total = size
+	return config
+}
+
+// TestParallelism is meant to be run with -race as well, to check merging
+// fields concurrently shares no state.
+func TestParallelism(t *testing.T) {
+	src := newShardedConfig(100)
+	dst := shardedConfig{A: shard{Name: "kept", Weights: map[string]int{"extra": 1}}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithParallelism(4)); err != nil {
+		t.Fatal(err)
+	}
+	expected := newShardedConfig(100)
+	expected.A.Name = "kept"
+	expected.A.Weights["extra"] = 1
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected the concurrent merge to match the serial one")
+	}
+}
+
+func TestParallelismTrace(t *testing.T) {
+	dst := shardedConfig{}
+	src := newShardedConfig(1)
+
+	trace, err := mergo.MergeWithTrace(&dst, src, mergo.WithParallelism(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := mergo.MergeWithTrace(&shardedConfig{}, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(trace, serial) {
+		t.Errorf("expected the trace to follow the field order, got %v", trace)
+	}
+}
+
+func TestParallelismMaxFields(t *testing.T) {
+	dst := shardedConfig{}
+	err := mergo.Merge(&dst, newShardedConfig(10), mergo.WithParallelism(4), mergo.WithMaxFields(50))
+	if err != mergo.ErrTooManyFields {
+		t.Errorf("expected %v, got %v", mergo.ErrTooManyFields, err)
+	}
+}
+
+func BenchmarkMergeSerial(b *testing.B) {
+	benchmarkMergeSharded(b)
+}
+
+func BenchmarkMergeParallelism(b *testing.B) {
+	benchmarkMergeSharded(b, mergo.WithParallelism(8))
+}
+
+func benchmarkMergeSharded(b *testing.B, opts ...func(*mergo.Config)) {
+	src := newShardedConfig(2000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst := shardedConfig{}
+		if err := mergo.Merge(&dst, src, append(opts, mergo.WithMapDeepCopy)...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// pathElement is a step into a nested value: a struct field, a map key or a
+// slice index. Paths are only rendered when needed, so tracking them while
+// merging doesn't allocate strings.
+type pathElement struct {
+	field string
+	key   reflect.Value
+	index int
+	// source is the map key a field was bound by, see sourcePath.
+	source string
+}
+
+func pushField(config *Config, name string) {
+	config.path = append(config.path, pathElement{field: name})
+}
+
+func pushKey(config *Config, key reflect.Value) {
+	config.path = append(config.path, pathElement{key: key})
+}
+
+func pushIndex(config *Config, index int) {
+	config.path = append(config.path, pathElement{index: index})
+}
+
+func popPath(config *Config) {
+	config.path = config.path[:len(config.path)-1]
+}
+
+// currentPath renders the path of the value being merged, like
+// Spec.Listeners[2].Port or Labels[app].
+func currentPath(config *Config) string {
+	return renderPath(config.path)
+}
+
+// sourcePath renders the path of the value being bound like currentPath, but
+// naming the fields bound by Map by the keys of the src map instead, like
+// tLS.ciphers.
+func sourcePath(config *Config) string {
+	path := make([]pathElement, len(config.path))
+	for i, e := range config.path {
+		if path[i] = e; e.source != "" {
+			path[i].field = e.source
+		}
+	}
+	return renderPath(path)
+}
+
+func renderPath(path []pathElement) string {
+	var b strings.Builder
+	for _, e := range path {
+		switch {
+		case e.field != "":
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(e.field)
+		case e.key.IsValid():
+			b.WriteByte('[')
+			if e.key.CanInterface() {
+				fmt.Fprint(&b, e.key.Interface())
+			} else {
+				b.WriteString(e.key.String())
+			}
+			b.WriteByte(']')
+		default:
+			b.WriteByte('[')
+			b.WriteString(strconv.Itoa(e.index))
+			b.WriteByte(']')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package mergo_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func TestPreserveNumericType(t *testing.T) {
+	dst := map[string]interface{}{
+		"port":    8080,
+		"retries": int8(3),
+		"ratio":   float32(0.5),
+		"nested":  map[string]interface{}{"workers": uint(2)},
+	}
+	var src map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"port": 9090, "retries": 5, "ratio": 0.25, "nested": {"workers": 4}, "added": 1}`), &src); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithPreserveNumericType); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"port":    9090,
+		"retries": int8(5),
+		"ratio":   float32(0.25),
+		"nested":  map[string]interface{}{"workers": uint(4)},
+		"added":   float64(1),
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %#v, got %#v", expected, dst)
+	}
+}
+
+func TestPreserveNumericTypeInexact(t *testing.T) {
+	dst := map[string]interface{}{"port": 8080, "retries": int8(3)}
+	src := map[string]interface{}{"port": 1.5, "retries": float64(300)}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithPreserveNumericType); err != nil {
+		t.Fatal(err)
+	}
+	if dst["port"] != 1.5 || dst["retries"] != float64(300) {
+		t.Errorf("expected values that don't fit to be kept as float64, got %#v", dst)
+	}
+}
+
+func TestPreserveNumericTypeDisabled(t *testing.T) {
+	dst := map[string]interface{}{"port": 8080}
+	src := map[string]interface{}{"port": float64(9090)}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst["port"] != float64(9090) {
+		t.Errorf("expected the float64 to replace the int without the option, got %#v", dst)
+	}
+}
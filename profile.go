@@ -0,0 +1,44 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+// Profile is a named preset of options for a common merge scenario, see
+// WithProfile.
+type Profile int
+
+const (
+	// ProfileJSONPatch applies src over dst like a JSON merge patch: every
+	// non-empty src value wins, and a non-nil empty slice clears the dst one.
+	ProfileJSONPatch Profile = iota + 1
+	// ProfileDefaults fills the empty dst values with src ones, deep copying
+	// maps so dst doesn't share them with the defaults.
+	ProfileDefaults
+	// ProfileReplace makes src win over dst everywhere, even with empty values.
+	ProfileReplace
+)
+
+// WithProfile will set the options of the given profile. Combining different
+// profiles makes merge and Map fail with ErrConflictingProfiles, while other
+// options can still be combined with any of them.
+func WithProfile(profile Profile) func(*Config) {
+	return func(config *Config) {
+		if config.profile != 0 && config.profile != profile {
+			config.profileConflict = true
+		}
+		config.profile = profile
+		switch profile {
+		case ProfileJSONPatch:
+			config.Overwrite = true
+			config.overwriteSliceWithEmptyValue = true
+		case ProfileDefaults:
+			config.Overwrite = false
+			config.mapDeepCopy = true
+		case ProfileReplace:
+			config.Overwrite = true
+			config.overwriteWithEmptyValue = true
+			config.overwriteSliceWithEmptyValue = true
+		}
+	}
+}
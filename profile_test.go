@@ -0,0 +1,57 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type profileConfig struct {
+	Name   string
+	Port   int
+	Hosts  []string
+	Labels map[string]string
+}
+
+func TestProfiles(t *testing.T) {
+	for _, tc := range []struct {
+		profile   mergo.Profile
+		overwrite bool
+		expected  profileConfig
+	}{
+		{mergo.ProfileJSONPatch, true, profileConfig{Name: "src", Port: 80, Hosts: []string{}, Labels: map[string]string{"a": "dst", "b": "src"}}},
+		{mergo.ProfileDefaults, false, profileConfig{Name: "dst", Port: 80, Hosts: []string{"dst"}, Labels: map[string]string{"a": "dst", "b": "src"}}},
+		{mergo.ProfileReplace, true, profileConfig{Name: "src", Hosts: []string{}, Labels: map[string]string{"a": "dst", "b": "src"}}},
+	} {
+		config := &mergo.Config{}
+		mergo.WithProfile(tc.profile)(config)
+		if config.Overwrite != tc.overwrite || config.AppendSlice {
+			t.Errorf("profile %d: unexpected config %+v", tc.profile, config)
+		}
+
+		dst := profileConfig{Name: "dst", Port: 80, Hosts: []string{"dst"}, Labels: map[string]string{"a": "dst"}}
+		src := profileConfig{Name: "src", Hosts: []string{}, Labels: map[string]string{"b": "src"}}
+		if err := mergo.Merge(&dst, src, mergo.WithProfile(tc.profile)); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(dst, tc.expected) {
+			t.Errorf("profile %d: expected %+v, got %+v", tc.profile, tc.expected, dst)
+		}
+	}
+}
+
+func TestConflictingProfiles(t *testing.T) {
+	var dst profileConfig
+	src := profileConfig{Name: "src"}
+
+	if err := mergo.Merge(&dst, src, mergo.WithProfile(mergo.ProfileDefaults), mergo.WithProfile(mergo.ProfileReplace)); err != mergo.ErrConflictingProfiles {
+		t.Errorf("expected %v, got %v", mergo.ErrConflictingProfiles, err)
+	}
+	if err := mergo.Map(&dst, map[string]interface{}{"name": "src"}, mergo.WithProfile(mergo.ProfileJSONPatch), mergo.WithProfile(mergo.ProfileDefaults)); err != mergo.ErrConflictingProfiles {
+		t.Errorf("expected %v from Map, got %v", mergo.ErrConflictingProfiles, err)
+	}
+	if err := mergo.Merge(&dst, src, mergo.WithProfile(mergo.ProfileReplace), mergo.WithProfile(mergo.ProfileReplace), mergo.WithAppendSlice); err != nil {
+		t.Errorf("expected a repeated profile to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"reflect"
+	"strings"
+)
+
+// isReadOnly reports whether field is tagged `mergo:"readonly"`, so no merge
+// modifies it, whatever the options.
+func isReadOnly(field reflect.StructField) bool {
+	tag, ok := field.Tag.Lookup("mergo")
+	if !ok {
+		return false
+	}
+	for _, option := range strings.Split(tag, ",") {
+		if option == "readonly" {
+			return true
+		}
+	}
+	return false
+}
+
+// protectField reports whether the field i of the struct dst is read-only,
+// tracing it as protected.
+func protectField(dst reflect.Value, i int, config *Config) bool {
+	field := &structFieldsOf(dst.Type())[i]
+	if !field.readOnly {
+		return false
+	}
+	if config.trace != nil {
+		pushField(config, field.Name)
+		traceDecision(config, TraceProtect, dst.Field(i), dst.Field(i))
+		popPath(config)
+	}
+	return true
+}
+
+// isReadOnlyByName reports whether the field of the struct type typ with the
+// given name is read-only, or promoted from a read-only embedded struct.
+func isReadOnlyByName(typ reflect.Type, name string) bool {
+	index, ok := fieldIndexesOf(typ)[name]
+	if !ok {
+		return false
+	}
+	for i := range index {
+		if isReadOnly(typ.FieldByIndex(index[:i+1])) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type readOnlyMeta struct {
+	Owner string
+}
+
+type readOnlyConfig struct {
+	ID           string `mergo:"readonly"`
+	Name         string
+	readOnlyMeta `mergo:"readonly"`
+	Version      int `json:"version" mergo:"readonly"`
+}
+
+func TestReadOnlyFields(t *testing.T) {
+	newDst := func() readOnlyConfig {
+		return readOnlyConfig{ID: "dst", Name: "dst", readOnlyMeta: readOnlyMeta{Owner: "dst"}, Version: 1}
+	}
+	src := readOnlyConfig{ID: "src", Name: "src", readOnlyMeta: readOnlyMeta{Owner: "src"}, Version: 2}
+	expected := readOnlyConfig{ID: "dst", Name: "src", readOnlyMeta: readOnlyMeta{Owner: "dst"}, Version: 1}
+
+	dst := newDst()
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithOverwriteWithEmptyValue); err != nil {
+		t.Fatal(err)
+	}
+	if dst != expected {
+		t.Errorf("expected %+v after Merge, got %+v", expected, dst)
+	}
+
+	dst = newDst()
+	if err := mergo.Map(&dst, map[string]interface{}{"iD": "src", "name": "src", "owner": "src", "version": 2}, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst != expected {
+		t.Errorf("expected %+v after Map, got %+v", expected, dst)
+	}
+
+	dst = newDst()
+	if err := mergo.MergeJSONPatch(&dst, []byte(`{"ID": "src", "Name": "src", "version": 2}`)); err != nil {
+		t.Fatal(err)
+	}
+	if dst != expected {
+		t.Errorf("expected %+v after MergeJSONPatch, got %+v", expected, dst)
+	}
+}
+
+func TestReadOnlyFieldsTrace(t *testing.T) {
+	dst := readOnlyConfig{ID: "dst"}
+	entries, err := mergo.MergeWithTrace(&dst, readOnlyConfig{ID: "src"}, mergo.WithOverride)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) == 0 || entries[0].Path != "ID" || entries[0].Action != mergo.TraceProtect {
+		t.Errorf("expected ID to be reported as protected, got %+v", entries)
+	}
+}
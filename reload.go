@@ -0,0 +1,95 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+// MergeReload applies to current, a pointer to a struct, only the values where
+// newCfg differs from baseline, the configuration current was loaded from, so
+// values set at runtime in current survive a reload that didn't touch them.
+// Structs and maps are compared field by field and key by key: a key removed
+// from baseline in newCfg is deleted from current. Any other changed value
+// replaces the one in current, even if empty. newCfg and baseline must have the
+// type current points to, or be pointers to it.
+func MergeReload(current, newCfg, baseline interface{}, opts ...func(*Config)) error {
+	if current == nil || newCfg == nil || baseline == nil {
+		return ErrNilArguments
+	}
+	vCurrent := reflect.ValueOf(current)
+	if vCurrent.Kind() != reflect.Ptr || vCurrent.IsNil() {
+		return ErrNonPointerAgument
+	}
+	vCurrent = vCurrent.Elem()
+	if vCurrent.Kind() != reflect.Struct {
+		return ErrNotSupported
+	}
+	vNew, vBase := reflect.Indirect(reflect.ValueOf(newCfg)), reflect.Indirect(reflect.ValueOf(baseline))
+	if !vNew.IsValid() || !vBase.IsValid() {
+		return ErrNilArguments
+	}
+	if vNew.Type() != vCurrent.Type() || vBase.Type() != vCurrent.Type() {
+		return ErrDifferentArgumentsTypes
+	}
+	config := &Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	reloadValue(vCurrent, vNew, vBase, config)
+	return nil
+}
+
+// reloadValue sets in current, a settable value, what changed from base to
+// next.
+func reloadValue(current, next, base reflect.Value, config *Config) {
+	if reflect.DeepEqual(next.Interface(), base.Interface()) {
+		return
+	}
+	switch current.Kind() {
+	case reflect.Struct:
+		for i, n := 0, current.NumField(); i < n; i++ {
+			if !current.Field(i).CanSet() || protectField(current, i, config) {
+				continue
+			}
+			reloadValue(current.Field(i), next.Field(i), base.Field(i), config)
+		}
+		return
+	case reflect.Ptr:
+		if !current.IsNil() && !next.IsNil() && !base.IsNil() && current.Elem().Kind() == reflect.Struct {
+			reloadValue(current.Elem(), next.Elem(), base.Elem(), config)
+			return
+		}
+	case reflect.Map:
+		if !current.IsNil() && !next.IsNil() && !base.IsNil() {
+			reloadMap(current, next, base, config)
+			return
+		}
+	}
+	current.Set(deepCopy(next, make(map[copyKey]reflect.Value)))
+}
+
+// reloadMap sets in current, a map, the keys added or changed from base to
+// next, and deletes those next removed.
+func reloadMap(current, next, base reflect.Value, config *Config) {
+	for _, key := range mapKeys(base, config) {
+		if !next.MapIndex(key).IsValid() {
+			current.SetMapIndex(key, reflect.Value{})
+		}
+	}
+	for _, key := range mapKeys(next, config) {
+		nextValue, baseValue := next.MapIndex(key), base.MapIndex(key)
+		if baseValue.IsValid() && reflect.DeepEqual(nextValue.Interface(), baseValue.Interface()) {
+			continue
+		}
+		value := reflect.New(current.Type().Elem()).Elem()
+		if currentValue := current.MapIndex(key); currentValue.IsValid() && baseValue.IsValid() {
+			// Map values aren't addressable, so changes are made to a copy.
+			value.Set(deepCopy(currentValue, make(map[copyKey]reflect.Value)))
+			reloadValue(value, nextValue, baseValue, config)
+		} else {
+			value.Set(deepCopy(nextValue, make(map[copyKey]reflect.Value)))
+		}
+		current.SetMapIndex(key, value)
+	}
+}
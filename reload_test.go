@@ -0,0 +1,73 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type reloadedServer struct {
+	Host     string
+	Port     int
+	LogLevel string
+	Limits   map[string]int
+	Backends []string
+	TLS      *reloadedTLS
+}
+
+type reloadedTLS struct {
+	Cert string
+	Key  string
+}
+
+func TestMergeReload(t *testing.T) {
+	baseline := reloadedServer{
+		Host:     "localhost",
+		Port:     8080,
+		LogLevel: "info",
+		Limits:   map[string]int{"conns": 100, "rps": 10},
+		Backends: []string{"a"},
+		TLS:      &reloadedTLS{Cert: "old.crt", Key: "old.key"},
+	}
+	current := baseline
+	current.Limits = map[string]int{"conns": 100, "rps": 10}
+	current.TLS = &reloadedTLS{Cert: "old.crt", Key: "old.key"}
+	// Set at runtime, like from an admin endpoint.
+	current.LogLevel = "debug"
+	current.Limits["conns"] = 500
+
+	newCfg := reloadedServer{
+		Host:     "localhost",
+		Port:     9090,
+		LogLevel: "info",
+		Limits:   map[string]int{"conns": 100, "burst": 20},
+		TLS:      &reloadedTLS{Cert: "new.crt", Key: "old.key"},
+	}
+	if err := mergo.MergeReload(&current, newCfg, &baseline); err != nil {
+		t.Fatal(err)
+	}
+	expected := reloadedServer{
+		Host:     "localhost",
+		Port:     9090,
+		LogLevel: "debug",
+		Limits:   map[string]int{"conns": 500, "burst": 20},
+		TLS:      &reloadedTLS{Cert: "new.crt", Key: "old.key"},
+	}
+	if !reflect.DeepEqual(current, expected) {
+		t.Errorf("expected %+v, got %+v", expected, current)
+	}
+	if baseline.TLS.Cert != "old.crt" || baseline.Limits["conns"] != 100 {
+		t.Errorf("expected baseline to be left as it was, got %+v", baseline)
+	}
+}
+
+func TestMergeReloadErrors(t *testing.T) {
+	current := reloadedServer{}
+	if err := mergo.MergeReload(current, reloadedServer{}, reloadedServer{}); err != mergo.ErrNonPointerAgument {
+		t.Errorf("expected %v, got %v", mergo.ErrNonPointerAgument, err)
+	}
+	if err := mergo.MergeReload(&current, reloadedTLS{}, reloadedServer{}); err != mergo.ErrDifferentArgumentsTypes {
+		t.Errorf("expected %v, got %v", mergo.ErrDifferentArgumentsTypes, err)
+	}
+}
@@ -0,0 +1,81 @@
+package mergo_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type requiredConfig struct {
+	Name string
+	Port int
+	Tags []string
+}
+
+func TestRequiredFields(t *testing.T) {
+	testCases := []struct {
+		name    string
+		src     map[string]interface{}
+		missing string
+	}{
+		{"present", map[string]interface{}{"name": "api", "port": 0}, ""},
+		{"present with field name", map[string]interface{}{"Name": "api", "Port": 80}, ""},
+		{"absent", map[string]interface{}{"name": "api"}, "Port"},
+		{"nil", map[string]interface{}{"name": nil, "port": 80}, "Name"},
+		{"typed nil", map[string]interface{}{"name": "api", "port": 80, "tags": []string(nil)}, "Tags"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := requiredConfig{}
+			required := []string{"Name", "Port"}
+			if tc.name == "typed nil" {
+				required = append(required, "Tags")
+			}
+			err := mergo.Map(&dst, tc.src, mergo.WithRequiredFields(required))
+			if tc.missing == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, mergo.ErrMissingRequiredField) || !strings.HasSuffix(err.Error(), tc.missing) {
+				t.Errorf("expected %v naming %s, got %v", mergo.ErrMissingRequiredField, tc.missing, err)
+			}
+			if dst.Name != "" || dst.Port != 0 {
+				t.Errorf("expected dst to be untouched, got %+v", dst)
+			}
+		})
+	}
+}
+
+type taggedRequiredConfig struct {
+	Port int `cfg:"listen_port"`
+	Host string
+}
+
+func TestRequiredFieldsWithTags(t *testing.T) {
+	src := map[string]interface{}{"listen_port": 80, "host": "localhost"}
+	for name, opt := range map[string]func(*mergo.Config){
+		"tag key":  mergo.WithTagKey("cfg"),
+		"tag name": mergo.WithTagName("cfg"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			dst := taggedRequiredConfig{}
+			if err := mergo.Map(&dst, src, opt, mergo.WithRequiredFields([]string{"Port", "Host"})); err != nil {
+				t.Fatalf("expected the tagged key to count for its field, got %v", err)
+			}
+			if dst.Port != 80 || dst.Host != "localhost" {
+				t.Errorf("expected src to be bound, got %+v", dst)
+			}
+		})
+	}
+
+	// Fields with a key set by their tags can only be bound by it.
+	dst := taggedRequiredConfig{}
+	err := mergo.Map(&dst, map[string]interface{}{"port": 80}, mergo.WithTagKey("cfg"), mergo.WithRequiredFields([]string{"Port"}))
+	if !errors.Is(err, mergo.ErrMissingRequiredField) {
+		t.Errorf("expected %v, got %v", mergo.ErrMissingRequiredField, err)
+	}
+}
@@ -0,0 +1,80 @@
+package mergo_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imdario/mergo"
+)
+
+type roundTripInner struct {
+	A int
+}
+
+type roundTripConfig struct {
+	Count   int
+	Small   int8
+	Size    uint64
+	Ratio   float64
+	Data    []byte
+	Tags    []string
+	Labels  map[string]int
+	Inner   roundTripInner
+	Pointer *roundTripInner
+	Created time.Time
+	Enabled bool
+}
+
+func TestRoundTripSafety(t *testing.T) {
+	src := roundTripConfig{
+		Count:   1,
+		Small:   -2,
+		Size:    math.MaxUint64,
+		Ratio:   1.5,
+		Data:    []byte("data"),
+		Tags:    []string{"a"},
+		Labels:  map[string]int{"a": 1},
+		Inner:   roundTripInner{A: 3},
+		Pointer: &roundTripInner{A: 4},
+		Created: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC),
+		Enabled: true,
+	}
+	m := map[string]interface{}{}
+	if err := mergo.Map(&m, src, mergo.WithRoundTripSafety); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m["data"].([]byte); !ok {
+		t.Errorf("expected data to be kept as bytes, got %T", m["data"])
+	}
+	if _, ok := m["count"].(int); !ok {
+		t.Errorf("expected count to be kept as an int, got %T", m["count"])
+	}
+
+	out := roundTripConfig{}
+	if err := mergo.Map(&out, m, mergo.WithRoundTripSafety); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, src) {
+		t.Errorf("expected %+v, got %+v", src, out)
+	}
+}
+
+func TestRoundTripSafetyNumericCoercion(t *testing.T) {
+	out := struct{ Ratio float64 }{}
+	src := map[string]interface{}{"ratio": int64(1<<53 + 1)}
+	err := mergo.Map(&out, src, mergo.WithNumericCoercion, mergo.WithRoundTripSafety)
+	if err == nil || !strings.Contains(err.Error(), "loses precision") {
+		t.Errorf("expected a precision error, got %v", err)
+	}
+
+	src = map[string]interface{}{"ratio": int64(1 << 53)}
+	if err := mergo.Map(&out, src, mergo.WithNumericCoercion, mergo.WithRoundTripSafety); err != nil {
+		t.Fatal(err)
+	}
+	if out.Ratio != 1<<53 {
+		t.Errorf("expected %v, got %v", 1<<53, out.Ratio)
+	}
+}
@@ -0,0 +1,59 @@
+package mergo_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type saturatedConfig struct {
+	Small  int8
+	Port   uint16
+	Count  int64
+	Size   uint32
+	Ratio  float32
+	Offset *int8
+}
+
+func TestSaturatingNumeric(t *testing.T) {
+	dst := saturatedConfig{}
+	src := map[string]interface{}{
+		"small":  300.0,
+		"port":   -1,
+		"count":  1e20,
+		"size":   uint64(1) << 40,
+		"ratio":  1e300,
+		"offset": -1000,
+	}
+
+	if err := mergo.Map(&dst, src, mergo.WithSaturatingNumeric, mergo.WithCanonicalizePointers); err != nil {
+		t.Fatal(err)
+	}
+	expected := saturatedConfig{Small: math.MaxInt8, Count: math.MaxInt64, Size: math.MaxUint32, Ratio: math.MaxFloat32}
+	if dst.Offset == nil || *dst.Offset != math.MinInt8 {
+		t.Errorf("expected the offset to clamp to %d, got %v", math.MinInt8, dst.Offset)
+	}
+	dst.Offset = nil
+	if dst != expected {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+}
+
+func TestSaturatingNumericFraction(t *testing.T) {
+	dst := saturatedConfig{}
+	err := mergo.Map(&dst, map[string]interface{}{"small": 300.5}, mergo.WithSaturatingNumeric)
+	if err == nil || !strings.Contains(err.Error(), "is not an integer") {
+		t.Errorf("expected fractions to still fail, got %v", err)
+	}
+}
+
+func TestSaturatingNumericRoundTripSafety(t *testing.T) {
+	dst := saturatedConfig{}
+	err := mergo.Map(&dst, map[string]interface{}{"small": 300}, mergo.WithSaturatingNumeric, mergo.WithRoundTripSafety)
+	if !errors.Is(err, mergo.ErrConflictingOptions) {
+		t.Errorf("expected %v, got %v", mergo.ErrConflictingOptions, err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"context"
+	"reflect"
+)
+
+// HookContext gives scoped transformers access to the merge calling them.
+type HookContext struct {
+	config *Config
+}
+
+// Scope returns the value attached to key using WithScope, if any.
+func (c *HookContext) Scope(key interface{}) (interface{}, bool) {
+	value, ok := c.config.scope[key]
+	return value, ok
+}
+
+// Context returns the context given to MergeContext, or context.Background.
+func (c *HookContext) Context() context.Context {
+	if c.config.ctx == nil {
+		return context.Background()
+	}
+	return c.config.ctx
+}
+
+// Path returns the path of the value being merged, like Spec.Listeners[2].Port.
+func (c *HookContext) Path() string {
+	return currentPath(c.config)
+}
+
+// ScopedTransformers can be implemented by Transformers to get the context of
+// the merge, like the tenant attached to it using WithScope, so the same
+// transformers can serve different merges without globals. Their scoped
+// transformer for a type, if any, is used instead of the plain one.
+type ScopedTransformers interface {
+	ScopedTransformer(reflect.Type) func(ctx *HookContext, dst, src reflect.Value) error
+}
+
+// ContextTransformers can be implemented by Transformers to get the context
+// given to MergeContext, so long-running transformers can honor its
+// cancellation. Their context transformer for a type, if any, is used instead
+// of the scoped or plain one.
+type ContextTransformers interface {
+	ContextTransformer(reflect.Type) func(ctx context.Context, dst, src reflect.Value) error
+}
+
+// WithScope will attach value to merge under key, so scoped transformers can
+// get it through their HookContext.
+func WithScope(key, value interface{}) func(*Config) {
+	return func(config *Config) {
+		if config.scope == nil {
+			config.scope = make(map[interface{}]interface{})
+		}
+		config.scope[key] = value
+	}
+}
@@ -0,0 +1,59 @@
+package mergo_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type tenantKey struct{}
+
+type tenantConfig struct {
+	Bucket string
+	Region string
+}
+
+// tenantPrefixer prefixes the merged buckets with the tenant of the merge.
+type tenantPrefixer struct{}
+
+func (tenantPrefixer) Transformer(reflect.Type) func(dst, src reflect.Value) error {
+	return nil
+}
+
+func (tenantPrefixer) ScopedTransformer(typ reflect.Type) func(ctx *mergo.HookContext, dst, src reflect.Value) error {
+	if typ.Kind() != reflect.String {
+		return nil
+	}
+	return func(ctx *mergo.HookContext, dst, src reflect.Value) error {
+		tenant, ok := ctx.Scope(tenantKey{})
+		if !ok || ctx.Path() != "Bucket" {
+			dst.Set(src)
+			return nil
+		}
+		dst.SetString(tenant.(string) + "-" + strings.TrimPrefix(src.String(), tenant.(string)+"-"))
+		return nil
+	}
+}
+
+func TestScope(t *testing.T) {
+	src := tenantConfig{Bucket: "logs", Region: "eu"}
+	for _, tenant := range []string{"acme", "globex"} {
+		dst := tenantConfig{Bucket: "default", Region: "us"}
+		if err := mergo.Merge(&dst, src, mergo.WithTransformers(tenantPrefixer{}), mergo.WithScope(tenantKey{}, tenant)); err != nil {
+			t.Fatal(err)
+		}
+		if expected := (tenantConfig{Bucket: tenant + "-logs", Region: "eu"}); dst != expected {
+			t.Errorf("expected %+v for %s, got %+v", expected, tenant, dst)
+		}
+	}
+
+	dst := tenantConfig{Bucket: "default"}
+	if err := mergo.Merge(&dst, src, mergo.WithTransformers(tenantPrefixer{})); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Bucket != "logs" {
+		t.Errorf("expected no prefix without a scope, got %+v", dst)
+	}
+}
@@ -0,0 +1,70 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type quotas struct {
+	Name    string
+	CPU     int
+	Memory  float64
+	Volumes map[string]int
+}
+
+// keepLarger overwrites numbers only with larger ones, and anything else as
+// usual for empty dst values.
+func keepLarger(paths *[]string) func(string, reflect.Value, reflect.Value) bool {
+	return func(path string, dst, src reflect.Value) bool {
+		*paths = append(*paths, path)
+		dst, src = reflect.ValueOf(dst.Interface()), reflect.ValueOf(src.Interface())
+		switch dst.Kind() {
+		case reflect.Int:
+			return src.Int() > dst.Int()
+		case reflect.Float64:
+			return src.Float() > dst.Float()
+		}
+		return dst.IsZero()
+	}
+}
+
+func TestShouldOverwrite(t *testing.T) {
+	dst := quotas{Name: "dst", CPU: 4, Memory: 1.5, Volumes: map[string]int{"data": 10, "logs": 5}}
+	src := quotas{Name: "src", CPU: 2, Memory: 2.5, Volumes: map[string]int{"data": 20, "logs": 1, "tmp": 0}}
+
+	var paths []string
+	if err := mergo.Merge(&dst, src, mergo.WithShouldOverwrite(keepLarger(&paths)), mergo.WithDeterministicOrder); err != nil {
+		t.Fatal(err)
+	}
+	expected := quotas{Name: "dst", CPU: 4, Memory: 2.5, Volumes: map[string]int{"data": 20, "logs": 5, "tmp": 0}}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+	if expected := []string{"Name", "CPU", "Memory", "Volumes[data]", "Volumes[logs]"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected the hook to be called for %v, got %v", expected, paths)
+	}
+}
+
+func TestShouldOverwriteEmptyValues(t *testing.T) {
+	dst := quotas{Name: "dst", CPU: 4}
+	src := quotas{}
+
+	always := func(string, reflect.Value, reflect.Value) bool { return true }
+	if err := mergo.Merge(&dst, src, mergo.WithShouldOverwrite(always)); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "" || dst.CPU != 0 {
+		t.Errorf("expected empty src values to overwrite when the hook says so, got %+v", dst)
+	}
+
+	dst = quotas{}
+	never := func(string, reflect.Value, reflect.Value) bool { return false }
+	if err := mergo.Merge(&dst, quotas{Name: "src", CPU: 2}, mergo.WithShouldOverwrite(never)); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "" || dst.CPU != 0 {
+		t.Errorf("expected empty dst values to be kept when the hook says so, got %+v", dst)
+	}
+}
@@ -0,0 +1,83 @@
+package mergo_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type lockableSection struct {
+	Locked bool
+	Values map[string]string
+	Hosts  []string
+}
+
+type lockableConfig struct {
+	Name     string
+	Network  lockableSection
+	Storage  lockableSection
+	Sections map[string]lockableSection
+}
+
+// skipLocked skips the sections currently locked in dst.
+func skipLocked(path string, dst, src reflect.Value) bool {
+	section, ok := dst.Interface().(lockableSection)
+	return ok && section.Locked
+}
+
+func TestSkipIf(t *testing.T) {
+	dst := lockableConfig{
+		Network: lockableSection{Locked: true, Values: map[string]string{"a": "dst"}},
+		Storage: lockableSection{Values: map[string]string{"a": "dst"}},
+	}
+	src := lockableConfig{
+		Name:    "src",
+		Network: lockableSection{Values: map[string]string{"a": "src", "b": "src"}, Hosts: []string{"src"}},
+		Storage: lockableSection{Values: map[string]string{"a": "src", "b": "src"}, Hosts: []string{"src"}},
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithSkipIf(skipLocked)); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "src" {
+		t.Errorf("expected name to be merged, got %v", dst.Name)
+	}
+	if expected := (lockableSection{Locked: true, Values: map[string]string{"a": "dst"}}); !reflect.DeepEqual(dst.Network, expected) {
+		t.Errorf("expected the locked section to be untouched, got %+v", dst.Network)
+	}
+	if !reflect.DeepEqual(dst.Storage, src.Storage) {
+		t.Errorf("expected the unlocked section to be merged, got %+v", dst.Storage)
+	}
+}
+
+func TestSkipIfByPath(t *testing.T) {
+	var paths []string
+	skip := mergo.WithSkipIf(func(path string, dst, src reflect.Value) bool {
+		paths = append(paths, path)
+		return strings.HasPrefix(strings.ToLower(path), "storage")
+	})
+	dst := lockableConfig{}
+	src := lockableConfig{Name: "src", Storage: lockableSection{Hosts: []string{"src"}}}
+
+	if err := mergo.Merge(&dst, src, skip); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "src" || dst.Storage.Hosts != nil {
+		t.Errorf("expected Storage to be skipped, got %+v", dst)
+	}
+	for _, path := range paths {
+		if strings.HasPrefix(path, "Storage.") {
+			t.Errorf("expected the skipped subtree not to be visited, got %s", path)
+		}
+	}
+
+	out := lockableConfig{}
+	if err := mergo.Map(&out, map[string]interface{}{"name": "src", "storage": map[string]interface{}{"hosts": []string{"src"}}}, skip); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "src" || out.Storage.Hosts != nil {
+		t.Errorf("expected Map to skip storage, got %+v", out)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// unwrapInterface returns the value held by v if it is an interface.
+func unwrapInterface(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Interface {
+		return v.Elem()
+	}
+	return v
+}
+
+// appendSlice appends src to dst. A slice of interfaces accepts the elements of
+// any src slice assignable to it, whatever their concrete types, and gets deep
+// copies of them so dst doesn't share storage with src. The callback given by
+// WithOnAppend is called for every appended element.
+func appendSlice(dst, src reflect.Value, config *Config) (reflect.Value, error) {
+	if err := chargeBytes(config, int64(src.Len())*int64(dst.Type().Elem().Size())); err != nil {
+		return dst, err
+	}
+	appended := dst
+	if dst.Type().Elem().Kind() == reflect.Interface && src.Type().Elem().AssignableTo(dst.Type().Elem()) {
+		copies := make(map[copyKey]reflect.Value)
+		for i, n := 0, src.Len(); i < n; i++ {
+			if err := chargeCopy(config, src.Index(i)); err != nil {
+				return dst, err
+			}
+			appended = reflect.Append(appended, deepCopy(src.Index(i), copies))
+		}
+	} else if src.Type() != dst.Type() {
+		return dst, typeMismatch(config, fmt.Errorf("cannot append two slices with different type (%s, %s)", src.Type(), dst.Type()), dst.Type(), src.Type())
+	} else {
+		copied, err := srcCopy(src, config)
+		if err != nil {
+			return dst, err
+		}
+		appended = reflect.AppendSlice(dst, copied)
+	}
+	if config.onAppend != nil {
+		for i, n := dst.Len(), appended.Len(); i < n; i++ {
+			pushIndex(config, i)
+			config.onAppend(currentPath(config), appended.Index(i))
+			popPath(config)
+		}
+	}
+	return appended, nil
+}
+
+// isMapSlice reports whether v is a slice of maps, either by its element type
+// or because every element it holds is a map (e.g. []interface{} from JSON).
+func isMapSlice(v reflect.Value) bool {
+	if v.Kind() != reflect.Slice {
+		return false
+	}
+	switch v.Type().Elem().Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Interface:
+		for i, n := 0, v.Len(); i < n; i++ {
+			if unwrapInterface(v.Index(i)).Kind() != reflect.Map {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// mapSliceKey returns the value stored under key in the map held by element.
+func mapSliceKey(element reflect.Value, key string) (interface{}, bool) {
+	element = unwrapInterface(element)
+	if element.Kind() != reflect.Map || element.IsNil() {
+		return nil, false
+	}
+	k := reflect.ValueOf(key)
+	keyType := element.Type().Key()
+	if !k.Type().AssignableTo(keyType) {
+		if !k.Type().ConvertibleTo(keyType) {
+			return nil, false
+		}
+		k = k.Convert(keyType)
+	}
+	v := unwrapInterface(element.MapIndex(k))
+	if !v.IsValid() || !v.CanInterface() || !v.Type().Comparable() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// mergeKeys returns the fields matching the elements of dst and src in a by-key
+// merge, if any. Slices of structs are matched by WithSliceMergeByKeys ones or
+// by WithSliceDeepMergeByKey one, and slices of maps by WithSliceMergeByKeys
+// ones or by WithMapSliceMergeKey one.
+func mergeKeys(dst, src reflect.Value, config *Config) ([]string, bool) {
+	if len(config.sliceMergeKeys) > 0 && (isStructSlice(dst) && isStructSlice(src) || isMapSlice(dst) && isMapSlice(src)) {
+		return config.sliceMergeKeys, true
+	}
+	if config.sliceDeepMergeKey != "" && isStructSlice(dst) && isStructSlice(src) {
+		return []string{config.sliceDeepMergeKey}, true
+	}
+	if config.mapSliceMergeKey != "" && isMapSlice(dst) && isMapSlice(src) {
+		return []string{config.mapSliceMergeKey}, true
+	}
+	return nil, false
+}
+
+// isStructSlice reports whether v is a slice of structs or pointers to structs.
+func isStructSlice(v reflect.Value) bool {
+	if v.Kind() != reflect.Slice {
+		return false
+	}
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.Struct
+}
+
+// elementField returns the value held by element under name, either a field of
+// a struct (or pointer to struct) or a key of a map.
+func elementField(element reflect.Value, name string) (interface{}, bool) {
+	element = unwrapInterface(element)
+	if element.Kind() == reflect.Ptr {
+		if element.IsNil() {
+			return nil, false
+		}
+		element = element.Elem()
+	}
+	if element.Kind() != reflect.Struct {
+		return mapSliceKey(element, name)
+	}
+	v := fieldByName(element, name)
+	if !v.IsValid() || !v.CanInterface() || !v.Type().Comparable() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// compositeKey returns the key of element made of its keys fields. Several
+// fields form a tuple, so no two different combinations of values collide.
+func compositeKey(element reflect.Value, keys []string) (interface{}, bool) {
+	if len(keys) == 1 {
+		return keyField(element, keys[0])
+	}
+	tuple := reflect.New(reflect.ArrayOf(len(keys), interfaceType)).Elem()
+	for i, key := range keys {
+		v, ok := keyField(element, key)
+		if !ok {
+			return nil, false
+		}
+		if v != nil {
+			tuple.Index(i).Set(reflect.ValueOf(v))
+		}
+	}
+	return tuple.Interface(), true
+}
+
+// elementKey returns the key of element like compositeKey. Zero keys are no
+// keys with WithSliceDeepMergeByKey, so their elements are appended.
+func elementKey(element reflect.Value, keys []string, config *Config) (interface{}, bool) {
+	k, ok := compositeKey(element, keys)
+	if ok && config.sliceDeepMergeKey != "" && isEmptyValue(reflect.ValueOf(k)) {
+		return nil, false
+	}
+	return k, ok
+}
+
+// keyField returns the value of element under key like elementField, unless it
+// can't be used as a map key, like a slice held by an interface field.
+func keyField(element reflect.Value, key string) (interface{}, bool) {
+	v, ok := elementField(element, key)
+	if ok && v != nil && !reflect.TypeOf(v).Comparable() {
+		return nil, false
+	}
+	return v, ok
+}
+
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// isTombstone reports whether element is marked for deletion, that is it holds
+// a non-empty value under config.sliceMergeByKeyTombstone.
+func isTombstone(element reflect.Value, config *Config) bool {
+	if config.sliceMergeByKeyTombstone == "" {
+		return false
+	}
+	v, ok := elementField(element, config.sliceMergeByKeyTombstone)
+	return ok && !isEmptyValue(reflect.ValueOf(v))
+}
+
+// deepMergeSliceByKeys merges every element in src into the element in dst
+// holding the same values under keys. Elements without a match, or without
+// the keys at all, are appended. Tombstones remove their match instead.
+// The merged slice is returned, dst is untouched.
+func deepMergeSliceByKeys(dst, src reflect.Value, keys []string, visited map[visit]bool, depth int, config *Config) (reflect.Value, error) {
+	if !src.Type().Elem().AssignableTo(dst.Type().Elem()) {
+		return dst, typeMismatch(config, fmt.Errorf("cannot merge by key two slices with different type (%s, %s)", src.Type(), dst.Type()), dst.Type(), src.Type())
+	}
+	merged := reflect.MakeSlice(dst.Type(), dst.Len(), dst.Len()+src.Len())
+	reflect.Copy(merged, dst)
+	index := make(map[interface{}]int, dst.Len())
+	for i, n := 0, dst.Len(); i < n; i++ {
+		if k, ok := elementKey(dst.Index(i), keys, config); ok {
+			if _, found := index[k]; !found {
+				index[k] = i
+			}
+		}
+	}
+	removed := make(map[int]bool)
+	for i, n := 0, src.Len(); i < n; i++ {
+		srcElement := src.Index(i)
+		if isTombstone(srcElement, config) {
+			if k, ok := elementKey(srcElement, keys, config); ok {
+				if j, found := index[k]; found {
+					removed[j] = true
+					delete(index, k)
+				}
+			}
+			continue
+		}
+		if k, ok := elementKey(srcElement, keys, config); ok {
+			if j, found := index[k]; found {
+				dstElement := unwrapInterface(merged.Index(j))
+				pushIndex(config, j)
+				resolved, err := resolveElementConflict(merged.Index(j), dstElement, unwrapInterface(srcElement), config)
+				if !resolved {
+					err = deepMerge(dstElement, unwrapInterface(srcElement), visited, depth+1, config)
+				}
+				popPath(config)
+				if err != nil {
+					return dst, err
+				}
+				continue
+			}
+			index[k] = merged.Len()
+		}
+		copied, err := srcCopy(srcElement, config)
+		if err != nil {
+			return dst, err
+		}
+		merged = reflect.Append(merged, copied)
+	}
+	if len(removed) == 0 {
+		return merged, nil
+	}
+	kept := reflect.MakeSlice(dst.Type(), 0, merged.Len()-len(removed))
+	for i, n := 0, merged.Len(); i < n; i++ {
+		if !removed[i] {
+			kept = reflect.Append(kept, merged.Index(i))
+		}
+	}
+	return kept, nil
+}
+
+// mergeStringsByIndex sets the elements of dst, a string slice, to the non-empty
+// elements of src at the same index, see WithTreatEmptyStringSliceElementsAsAbsent.
+// Only the indexes found in both slices are merged.
+func mergeStringsByIndex(dst, src reflect.Value, overwrite bool, config *Config) {
+	for i := 0; i < src.Len() && i < dst.Len(); i++ {
+		srcElement, dstElement := src.Index(i), dst.Index(i)
+		pushIndex(config, i)
+		if srcElement.Len() == 0 || !(overwrite || dstElement.Len() == 0) {
+			traceDecision(config, TraceKeep, dstElement, dstElement)
+		} else {
+			traceDecision(config, TraceSet, dstElement, srcElement)
+			dstElement.SetString(srcElement.String())
+		}
+		popPath(config)
+	}
+}
+
+func sliceLengthMismatch(dst, src reflect.Value, config *Config) error {
+	return fmt.Errorf("%w at %q: dst has %d elements, src %d", ErrSliceLengthMismatch, currentPath(config), dst.Len(), src.Len())
+}
+
+// resolveElementConflict sets slot, the dst element holding dstElement, to the
+// element returned by the resolver given by WithElementConflictResolver when
+// both dstElement and srcElement are non-empty, or to the most complete one
+// with WithMostCompleteWins. It reports whether it did.
+func resolveElementConflict(slot, dstElement, srcElement reflect.Value, config *Config) (bool, error) {
+	if config.elementConflictResolver == nil && !config.mostCompleteWins || isEmptyElement(dstElement) || isEmptyElement(srcElement) {
+		return false, nil
+	}
+	resolved, ok := mostComplete(dstElement, srcElement, config)
+	if !ok {
+		if config.elementConflictResolver == nil {
+			return false, nil
+		}
+		var err error
+		if resolved, err = config.elementConflictResolver(dstElement, srcElement); err != nil {
+			return true, err
+		}
+	}
+	if !resolved.IsValid() {
+		return true, fmt.Errorf("%w: no element resolved at %s", ErrInvalidValue, currentPath(config))
+	}
+	if !resolved.Type().AssignableTo(slot.Type()) {
+		return true, typeMismatch(config, ErrDifferentArgumentsTypes, slot.Type(), resolved.Type())
+	}
+	traceDecision(config, TraceSet, slot, resolved)
+	slot.Set(resolved)
+	return true, nil
+}
+
+func isEmptyElement(v reflect.Value) bool {
+	return isEmptyValue(v) || v.Kind() == reflect.Struct && isEmptyStruct(v)
+}
@@ -0,0 +1,72 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type envVar struct {
+	Name  string
+	Value string
+	From  string
+}
+
+type container struct {
+	Env   []envVar
+	Ports []*envVar
+	Args  []string
+}
+
+func TestSliceDeepMergeByKey(t *testing.T) {
+	dst := container{
+		Env:  []envVar{{Name: "HOME", Value: "/root"}, {Name: "PATH", Value: "/bin"}},
+		Args: []string{"-v"},
+	}
+	src := container{
+		Env:  []envVar{{Name: "PATH", Value: "/usr/bin", From: "env"}, {Name: "USER", Value: "ana"}, {Value: "anonymous"}},
+		Args: []string{"-q"},
+	}
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepMergeByKey("Name")); err != nil {
+		t.Fatal(err)
+	}
+	expected := []envVar{
+		{Name: "HOME", Value: "/root"},
+		{Name: "PATH", Value: "/bin", From: "env"},
+		{Name: "USER", Value: "ana"},
+		{Value: "anonymous"},
+	}
+	if !reflect.DeepEqual(dst.Env, expected) {
+		t.Errorf("expected %v, got %v", expected, dst.Env)
+	}
+	if !reflect.DeepEqual(dst.Args, []string{"-v"}) {
+		t.Errorf("expected non-struct slices to be kept, got %v", dst.Args)
+	}
+}
+
+func TestSliceDeepMergeByKeyWithOverride(t *testing.T) {
+	dst := container{Env: []envVar{{Name: "PATH", Value: "/bin"}}}
+	src := container{Env: []envVar{{Name: "PATH", Value: "/usr/bin"}}}
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepMergeByKey("Name"), mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []envVar{{Name: "PATH", Value: "/usr/bin"}}; !reflect.DeepEqual(dst.Env, expected) {
+		t.Errorf("expected %v, got %v", expected, dst.Env)
+	}
+}
+
+func TestSliceDeepMergeByKeyPointers(t *testing.T) {
+	http := &envVar{Name: "http", Value: "80"}
+	dst := container{Ports: []*envVar{http}}
+	src := container{Ports: []*envVar{{Name: "http", From: "service"}, {Name: "https", Value: "443"}}}
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepMergeByKey("Name")); err != nil {
+		t.Fatal(err)
+	}
+	if len(dst.Ports) != 2 || dst.Ports[0] != http || dst.Ports[1].Name != "https" {
+		t.Fatalf("expected http to be merged and https appended, got %v", dst.Ports)
+	}
+	if *http != (envVar{Name: "http", Value: "80", From: "service"}) {
+		t.Errorf("expected http to be deep merged, got %v", *http)
+	}
+}
@@ -0,0 +1,96 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type keyedResource struct {
+	Namespace string
+	Name      string
+	Replicas  int
+	Image     string
+	Deleted   bool
+}
+
+type keyedResources struct {
+	Resources []keyedResource
+	Pointers  []*keyedResource
+}
+
+func TestSliceMergeByKeys(t *testing.T) {
+	dst := keyedResources{Resources: []keyedResource{
+		{Namespace: "a", Name: "web", Replicas: 1},
+		{Namespace: "b", Name: "web", Replicas: 2},
+		{Namespace: "a-b", Name: "c", Replicas: 3},
+	}}
+	src := keyedResources{Resources: []keyedResource{
+		{Namespace: "b", Name: "web", Image: "nginx"},
+		{Namespace: "a", Name: "b-c", Image: "redis"},
+		{Namespace: "a", Name: "db", Image: "postgres"},
+	}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceMergeByKeys("Namespace", "Name")); err != nil {
+		t.Fatal(err)
+	}
+	expected := []keyedResource{
+		{Namespace: "a", Name: "web", Replicas: 1},
+		{Namespace: "b", Name: "web", Replicas: 2, Image: "nginx"},
+		{Namespace: "a-b", Name: "c", Replicas: 3},
+		{Namespace: "a", Name: "b-c", Image: "redis"},
+		{Namespace: "a", Name: "db", Image: "postgres"},
+	}
+	if !reflect.DeepEqual(dst.Resources, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst.Resources)
+	}
+}
+
+func TestSliceMergeByKeysPointers(t *testing.T) {
+	dst := keyedResources{Pointers: []*keyedResource{{Namespace: "a", Name: "web", Replicas: 1}}}
+	src := keyedResources{Pointers: []*keyedResource{{Namespace: "a", Name: "web", Replicas: 5, Image: "nginx"}}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceMergeByKeys("Namespace", "Name"), mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if len(dst.Pointers) != 1 || *dst.Pointers[0] != (keyedResource{Namespace: "a", Name: "web", Replicas: 5, Image: "nginx"}) {
+		t.Errorf("expected the pointed elements to be merged, got %+v", dst.Pointers)
+	}
+}
+
+func TestSliceMergeByKeysMaps(t *testing.T) {
+	dst := map[string]interface{}{"items": []interface{}{
+		map[string]interface{}{"ns": "a", "name": "web", "port": 80},
+	}}
+	src := map[string]interface{}{"items": []interface{}{
+		map[string]interface{}{"ns": "a", "name": "web", "tls": true},
+		map[string]interface{}{"ns": "b", "name": "web"},
+	}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceMergeByKeys("ns", "name")); err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{
+		map[string]interface{}{"ns": "a", "name": "web", "port": 80, "tls": true},
+		map[string]interface{}{"ns": "b", "name": "web"},
+	}
+	if !reflect.DeepEqual(dst["items"], expected) {
+		t.Errorf("expected %v, got %v", expected, dst["items"])
+	}
+}
+
+func TestSliceMergeByKeysTombstone(t *testing.T) {
+	dst := keyedResources{Resources: []keyedResource{
+		{Namespace: "a", Name: "web"},
+		{Namespace: "a", Name: "db"},
+	}}
+	src := keyedResources{Resources: []keyedResource{{Namespace: "a", Name: "db", Deleted: true}}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceMergeByKeys("Namespace", "Name"), mergo.WithSliceMergeByKeyTombstone("Deleted")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []keyedResource{{Namespace: "a", Name: "web"}}; !reflect.DeepEqual(dst.Resources, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst.Resources)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// deepMergeField merges the field i of the struct src into the one of dst,
+// passing it first through its named transform, and following the slice
+// strategy of its tag, see WithMergeStrategyTag.
+func deepMergeField(dst, src reflect.Value, i int, visited map[visit]bool, depth int, config *Config) error {
+	srcField, err := transformedField(src, i, config)
+	if err != nil {
+		return err
+	}
+	if config.mergeStrategyTag && dst.Field(i).Kind() == reflect.Slice {
+		if field := &structFieldsOf(dst.Type())[i]; field.hasSliceStrategy {
+			return deepMergeSliceWithStrategy(dst.Field(i), srcField, field.sliceStrategy, visited, depth+1, config)
+		}
+	}
+	return deepMerge(dst.Field(i), srcField, visited, depth+1, config)
+}
+
+// deepMergeSliceWithStrategy merges src into dst, slices, as set by strategy.
+func deepMergeSliceWithStrategy(dst, src reflect.Value, strategy string, visited map[visit]bool, depth int, config *Config) error {
+	if !dst.CanSet() || (isEmptyValue(src) && !config.overwriteWithEmptyValue) {
+		traceDecision(config, TraceKeep, dst, dst)
+		return nil
+	}
+	switch key := strings.TrimPrefix(strategy, "bykey:"); {
+	case strategy == "append":
+		appended, err := appendSlice(dst, src, config)
+		if err != nil {
+			return err
+		}
+		traceDecision(config, TraceAppend, dst, appended)
+		dst.Set(appended)
+	case strategy == "replace":
+		if !src.Type().AssignableTo(dst.Type()) {
+			return typeMismatch(config, ErrDifferentArgumentsTypes, dst.Type(), src.Type())
+		}
+		copied, err := srcCopy(src, config)
+		if err != nil {
+			return err
+		}
+		traceDecision(config, TraceSet, dst, copied)
+		dst.Set(copied)
+	case key != strategy && key != "":
+		if !(isStructSlice(dst) && isStructSlice(src) || isMapSlice(dst) && isMapSlice(src)) {
+			return fmt.Errorf("slice strategy %q needs slices of structs or maps for %s", strategy, currentPath(config))
+		}
+		merged, err := deepMergeSliceByKeys(dst, src, []string{key}, visited, depth+1, config)
+		if err != nil {
+			return err
+		}
+		traceDecision(config, TraceSet, dst, merged)
+		dst.Set(merged)
+	default:
+		return fmt.Errorf("unknown slice strategy %q for %s", strategy, currentPath(config))
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package mergo_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type strategyBackend struct {
+	ID     string
+	Weight int
+}
+
+type strategyConfig struct {
+	Plugins  []string          `mergo:"slice=append"`
+	Servers  []string          `mergo:"slice=replace"`
+	Backends []strategyBackend `mergo:"slice=bykey:ID"`
+	Tags     []string
+}
+
+func TestMergeStrategyTag(t *testing.T) {
+	dst := strategyConfig{
+		Plugins:  []string{"auth"},
+		Servers:  []string{"a", "b"},
+		Backends: []strategyBackend{{ID: "x", Weight: 1}, {ID: "y"}},
+		Tags:     []string{"base"},
+	}
+	src := strategyConfig{
+		Plugins:  []string{"metrics"},
+		Servers:  []string{"c"},
+		Backends: []strategyBackend{{ID: "y", Weight: 2}, {ID: "z", Weight: 3}},
+		Tags:     []string{"overlay"},
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithMergeStrategyTag); err != nil {
+		t.Fatal(err)
+	}
+	expected := strategyConfig{
+		Plugins:  []string{"auth", "metrics"},
+		Servers:  []string{"c"},
+		Backends: []strategyBackend{{ID: "x", Weight: 1}, {ID: "y", Weight: 2}, {ID: "z", Weight: 3}},
+		Tags:     []string{"base"},
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+}
+
+func TestMergeStrategyTagEmptySrc(t *testing.T) {
+	dst := strategyConfig{Servers: []string{"a"}}
+	if err := mergo.Merge(&dst, strategyConfig{}, mergo.WithMergeStrategyTag); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst.Servers, []string{"a"}) {
+		t.Errorf("expected an empty src to keep dst, got %v", dst.Servers)
+	}
+}
+
+func TestMergeStrategyTagUnknown(t *testing.T) {
+	type unknownStrategy struct {
+		Values []int `mergo:"slice=shuffle"`
+	}
+	dst := unknownStrategy{}
+	err := mergo.Merge(&dst, unknownStrategy{Values: []int{1}}, mergo.WithMergeStrategyTag)
+	if err == nil || !strings.Contains(err.Error(), `unknown slice strategy "shuffle"`) {
+		t.Errorf("expected an unknown strategy error, got %v", err)
+	}
+}
+
+func TestMergeStrategyTagReplaceWithDeepCopy(t *testing.T) {
+	dst := strategyConfig{Servers: []string{"a", "b"}}
+	src := strategyConfig{Servers: []string{"c"}}
+	if err := mergo.Merge(&dst, src, mergo.WithMergeStrategyTag, mergo.WithDeepCopy); err != nil {
+		t.Fatal(err)
+	}
+	src.Servers[0] = "changed"
+	if !reflect.DeepEqual(dst.Servers, []string{"c"}) {
+		t.Errorf("expected dst not to share the src slice, got %v", dst.Servers)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+// MergeWithSnapshot does the same as Merge, but first takes a deep copy of dst
+// and returns undo, which restores dst to it, so speculative merges can be
+// rolled back. undo is returned even if the merge fails midway, and it can be
+// called many times, restoring dst each time to the same pre-merge value.
+func MergeWithSnapshot(dst, src interface{}, opts ...func(*Config)) (undo func(), err error) {
+	vDst := reflect.ValueOf(dst)
+	if dst == nil || vDst.Kind() != reflect.Ptr || vDst.IsNil() {
+		return func() {}, ErrNonPointerAgument
+	}
+	snapshot := deepCopy(vDst.Elem(), make(map[copyKey]reflect.Value))
+	undo = func() {
+		vDst.Elem().Set(deepCopy(snapshot, make(map[copyKey]reflect.Value)))
+	}
+	return undo, merge(dst, src, opts...)
+}
@@ -0,0 +1,48 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type snapshotDoc struct {
+	Title string
+	Tags  []string
+	Meta  map[string]string
+	Owner *simpleTest
+}
+
+func TestMergeWithSnapshot(t *testing.T) {
+	dst := snapshotDoc{Title: "draft", Tags: []string{"a"}, Meta: map[string]string{"lang": "en"}, Owner: &simpleTest{Value: 1}}
+	original := snapshotDoc{Title: "draft", Tags: []string{"a"}, Meta: map[string]string{"lang": "en"}, Owner: &simpleTest{Value: 1}}
+	src := snapshotDoc{Title: "final", Tags: []string{"b"}, Meta: map[string]string{"lang": "fr", "tone": "formal"}, Owner: &simpleTest{Value: 2}}
+
+	undo, err := mergo.MergeWithSnapshot(&dst, src, mergo.WithOverride, mergo.WithAppendSlice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst.Title != "final" || len(dst.Tags) != 2 || dst.Meta["tone"] != "formal" || dst.Owner.Value != 2 {
+		t.Fatalf("expected the merge to be applied, got %+v", dst)
+	}
+
+	undo()
+	if !reflect.DeepEqual(dst, original) {
+		t.Errorf("expected %+v after undo, got %+v", original, dst)
+	}
+
+	dst.Meta["lang"] = "de"
+	undo()
+	if !reflect.DeepEqual(dst, original) {
+		t.Errorf("expected undo to restore the snapshot again, got %+v", dst)
+	}
+}
+
+func TestMergeWithSnapshotNonPointer(t *testing.T) {
+	undo, err := mergo.MergeWithSnapshot(snapshotDoc{}, snapshotDoc{})
+	if err != mergo.ErrNonPointerAgument {
+		t.Errorf("expected %v, got %v", mergo.ErrNonPointerAgument, err)
+	}
+	undo()
+}
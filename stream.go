@@ -0,0 +1,38 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+// MergeMapStream merges into dst the entries pulled from next, one at a time,
+// until it returns false, so a huge src map never needs to be built. Each
+// entry follows the same rules as if it was a key of a src map passed to
+// Merge, including overriding and emptiness. dst must not be nil.
+func MergeMapStream(dst map[string]interface{}, next func() (key string, val interface{}, ok bool), opts ...func(*Config)) error {
+	if dst == nil || next == nil {
+		return ErrNilArguments
+	}
+	config := &Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	vDst := reflect.ValueOf(dst)
+	entry := make(map[string]interface{}, 1)
+	vEntry := reflect.ValueOf(entry)
+	visited := make(map[visit]bool)
+	for {
+		key, val, ok := next()
+		if !ok {
+			break
+		}
+		entry[key] = val
+		err := deepMerge(vDst, vEntry, visited, 0, config)
+		delete(entry, key)
+		if err != nil {
+			return err
+		}
+	}
+	return collectErrors(config, nil)
+}
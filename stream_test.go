@@ -0,0 +1,84 @@
+package mergo_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+// entries returns a generator over the given key and value pairs.
+func entries(pairs ...interface{}) func() (string, interface{}, bool) {
+	i := 0
+	return func() (string, interface{}, bool) {
+		if i >= len(pairs) {
+			return "", nil, false
+		}
+		key, val := pairs[i].(string), pairs[i+1]
+		i += 2
+		return key, val, true
+	}
+}
+
+func TestMergeMapStream(t *testing.T) {
+	dst := map[string]interface{}{
+		"name":   "dst",
+		"port":   0,
+		"labels": map[string]interface{}{"env": "dev"},
+	}
+	next := entries(
+		"name", "src",
+		"port", 8080,
+		"labels", map[string]interface{}{"team": "core"},
+		"debug", true,
+	)
+
+	if err := mergo.MergeMapStream(dst, next); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"name":   "dst",
+		"port":   8080,
+		"labels": map[string]interface{}{"env": "dev", "team": "core"},
+		"debug":  true,
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestMergeMapStreamOverride(t *testing.T) {
+	dst := map[string]interface{}{"name": "dst", "port": 80}
+	if err := mergo.MergeMapStream(dst, entries("name", "src", "port", 0), mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]interface{}{"name": "src", "port": 0}; !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestMergeMapStreamLarge(t *testing.T) {
+	const n = 10000
+	i := 0
+	next := func() (string, interface{}, bool) {
+		if i == n {
+			return "", nil, false
+		}
+		i++
+		return strconv.Itoa(i), i, true
+	}
+	dst := map[string]interface{}{}
+	if err := mergo.MergeMapStream(dst, next); err != nil {
+		t.Fatal(err)
+	}
+	if len(dst) != n || dst["42"] != 42 {
+		t.Errorf("expected %d entries, got %d", n, len(dst))
+	}
+}
+
+func TestMergeMapStreamNil(t *testing.T) {
+	if err := mergo.MergeMapStream(nil, entries()); err != mergo.ErrNilArguments {
+		t.Errorf("expected %v, got %v", mergo.ErrNilArguments, err)
+	}
+}
@@ -0,0 +1,79 @@
+package mergo_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type shape interface {
+	Area() int
+}
+
+type square struct {
+	Side  int
+	Label string
+}
+
+func (s *square) Area() int {
+	return s.Side * s.Side
+}
+
+type strictConfig struct {
+	Shape shape
+	Any   interface{}
+}
+
+func TestStrictInterfacesNilDestination(t *testing.T) {
+	dst := strictConfig{}
+	src := strictConfig{Shape: &square{Side: 2}}
+
+	err := mergo.Merge(&dst, src, mergo.WithStrictInterfaces)
+	if !errors.Is(err, mergo.ErrNilInterfaceDestination) {
+		t.Fatalf("expected %v, got %v", mergo.ErrNilInterfaceDestination, err)
+	}
+
+	dst = strictConfig{}
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Shape != src.Shape {
+		t.Errorf("expected plain assignment without strict mode, got %v", dst.Shape)
+	}
+}
+
+func TestStrictInterfacesWithFactory(t *testing.T) {
+	dst := strictConfig{}
+	src := strictConfig{Shape: &square{Side: 2, Label: "small"}}
+	factory := mergo.WithInterfaceFactory(reflect.TypeOf((*shape)(nil)).Elem(), func() interface{} {
+		return &square{}
+	})
+
+	if err := mergo.Merge(&dst, src, mergo.WithStrictInterfaces, factory); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := dst.Shape.(*square)
+	if !ok {
+		t.Fatalf("expected a *square, got %T", dst.Shape)
+	}
+	if got == src.Shape {
+		t.Error("expected the factory value, got src's pointer")
+	}
+	if *got != (square{Side: 2, Label: "small"}) {
+		t.Errorf("expected src to be merged into the factory value, got %v", *got)
+	}
+}
+
+func TestStrictInterfacesNonNilDestination(t *testing.T) {
+	dst := strictConfig{Shape: &square{Side: 3}}
+	src := strictConfig{Shape: &square{Label: "big"}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithStrictInterfaces); err != nil {
+		t.Fatal(err)
+	}
+	if got := dst.Shape.(*square); *got != (square{Side: 3, Label: "big"}) {
+		t.Errorf("expected a deep merge into the existing value, got %v", *got)
+	}
+}
@@ -0,0 +1,44 @@
+package mergo_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type positionalConfig struct {
+	Ports  []int
+	ByName map[string]interface{}
+}
+
+func TestStrictSliceLength(t *testing.T) {
+	dst := positionalConfig{Ports: []int{80, 0}}
+	src := positionalConfig{Ports: []int{8080, 443}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepCopy, mergo.WithStrictSliceLength); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int{8080, 443}; !reflect.DeepEqual(dst.Ports, expected) {
+		t.Errorf("expected %v, got %v", expected, dst.Ports)
+	}
+
+	src = positionalConfig{Ports: []int{1, 2, 3}}
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepCopy, mergo.WithStrictSliceLength); !errors.Is(err, mergo.ErrSliceLengthMismatch) {
+		t.Errorf("expected %v, got %v", mergo.ErrSliceLengthMismatch, err)
+	}
+	if err := mergo.Merge(&dst, src, mergo.WithSliceDeepCopy); err != nil {
+		t.Errorf("expected no error without the option, got %v", err)
+	}
+}
+
+func TestStrictSliceLengthMapValues(t *testing.T) {
+	dst := positionalConfig{ByName: map[string]interface{}{"ports": []int{80}}}
+	src := positionalConfig{ByName: map[string]interface{}{"ports": []int{80, 443}}}
+
+	err := mergo.Merge(&dst, src, mergo.WithSliceDeepCopy, mergo.WithStrictSliceLength)
+	if !errors.Is(err, mergo.ErrSliceLengthMismatch) {
+		t.Errorf("expected %v, got %v", mergo.ErrSliceLengthMismatch, err)
+	}
+}
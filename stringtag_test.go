@@ -0,0 +1,46 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type stringTaggedConfig struct {
+	Port    int     `json:"port,string"`
+	Enabled bool    `json:"enabled,omitempty,string"`
+	Ratio   float64 `json:"ratio,string"`
+	Retries int     `json:"retries"`
+}
+
+func TestStringTagOption(t *testing.T) {
+	var dst stringTaggedConfig
+	src := map[string]interface{}{"port": "8080", "enabled": "true", "ratio": "0.5", "retries": 3}
+
+	if err := mergo.Map(&dst, src, mergo.WithTagName("json")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (stringTaggedConfig{Port: 8080, Enabled: true, Ratio: 0.5, Retries: 3}); dst != expected {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+
+	if err := mergo.Map(&dst, map[string]interface{}{"port": 9090}, mergo.WithTagName("json"), mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Port != 9090 {
+		t.Errorf("expected numbers to still bind, got %+v", dst)
+	}
+}
+
+func TestStringTagOptionErrors(t *testing.T) {
+	for _, src := range []map[string]interface{}{
+		{"port": "http"},
+		{"enabled": "maybe"},
+		{"retries": "3"},
+	} {
+		var dst stringTaggedConfig
+		if err := mergo.Map(&dst, src, mergo.WithTagName("json")); err == nil {
+			t.Errorf("expected an error binding %v, got %+v", src, dst)
+		}
+	}
+}
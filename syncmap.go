@@ -0,0 +1,55 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"reflect"
+	"sync"
+)
+
+// MergeSyncMap merges src into dst key by key with Load and Store, following
+// the same rules as Merge for every value: a key missing or empty in dst takes
+// the src value, and a set one is only replaced using WithOverride. Maps found
+// under a key in both are deep merged into a copy of the dst one, which is then
+// stored, so concurrent readers never see a map being modified.
+func MergeSyncMap(dst *sync.Map, src map[string]interface{}, opts ...func(*Config)) error {
+	if dst == nil || src == nil {
+		return ErrNilArguments
+	}
+	config := &Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	pushKey(config, reflect.Value{})
+	for _, key := range mapKeys(reflect.ValueOf(src), config) {
+		config.path[len(config.path)-1] = pathElement{key: key}
+		if err := countField(config); err != nil {
+			return err
+		}
+		srcValue := src[key.String()]
+		stored, loaded := dst.Load(key.String())
+		if !loaded || stored == nil {
+			dst.Store(key.String(), srcValue)
+			continue
+		}
+		dstElement, srcElement := reflect.ValueOf(stored), reflect.ValueOf(srcValue)
+		if srcElement.Kind() == reflect.Map && srcElement.Type() == dstElement.Type() {
+			merged := reflect.New(dstElement.Type()).Elem()
+			merged.Set(deepCopy(dstElement, make(map[copyKey]reflect.Value)))
+			if err := deepMerge(merged, srcElement, make(map[visit]bool), 1, config); err != nil {
+				return err
+			}
+			dst.Store(key.String(), merged.Interface())
+			continue
+		}
+		empty := isEmptyValue(srcElement)
+		if isEmptyValue(dstElement) && !empty || config.Overwrite && (!empty || config.overwriteWithEmptyValue) {
+			traceDecision(config, TraceSet, dstElement, srcElement)
+			dst.Store(key.String(), srcValue)
+		}
+	}
+	popPath(config)
+	return collectErrors(config, nil)
+}
@@ -0,0 +1,80 @@
+package mergo_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+func TestMergeSyncMap(t *testing.T) {
+	var dst sync.Map
+	dst.Store("name", "dst")
+	dst.Store("port", 0)
+	dst.Store("labels", map[string]interface{}{"app": "dst"})
+	src := map[string]interface{}{
+		"name":   "src",
+		"port":   8080,
+		"labels": map[string]interface{}{"app": "src", "tier": "web"},
+		"debug":  true,
+	}
+
+	if err := mergo.MergeSyncMap(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"name":   "dst",
+		"port":   8080,
+		"labels": map[string]interface{}{"app": "dst", "tier": "web"},
+		"debug":  true,
+	}
+	got := map[string]interface{}{}
+	dst.Range(func(key, value interface{}) bool {
+		got[key.(string)] = value
+		return true
+	})
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if err := mergo.MergeSyncMap(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := dst.Load("name"); name != "src" {
+		t.Errorf("expected name to be overridden, got %v", name)
+	}
+}
+
+func TestMergeSyncMapConcurrentReads(t *testing.T) {
+	var dst sync.Map
+	dst.Store("labels", map[string]interface{}{"app": "dst"})
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if labels, ok := dst.Load("labels"); ok {
+				for range labels.(map[string]interface{}) {
+				}
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		src := map[string]interface{}{"labels": map[string]interface{}{"app": "src", "n": i}}
+		if err := mergo.MergeSyncMap(&dst, src, mergo.WithOverride); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(done)
+	wg.Wait()
+	if labels, _ := dst.Load("labels"); labels.(map[string]interface{})["n"] != 99 {
+		t.Errorf("expected the last merge to win, got %v", labels)
+	}
+}
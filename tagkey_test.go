@@ -0,0 +1,81 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type taggedClient struct {
+	MaxRetries int    `json:"max_retries"`
+	ServerURL  string `json:"server-url,omitempty"`
+	Token      string `json:"-"`
+	Region     string `json:"region" mergo:"name=zone"`
+	Timeout    int
+}
+
+func TestTagKeyMapIntoStruct(t *testing.T) {
+	src := map[string]interface{}{
+		"max_retries": 3,
+		"server-url":  "https://example.com",
+		"token":       "secret",
+		"Token":       "secret",
+		"zone":        "eu-west-1",
+		"region":      "us-east-1",
+		"timeout":     30,
+	}
+	var unknown map[string]interface{}
+	dst := taggedClient{}
+
+	if err := mergo.Map(&dst, src, mergo.WithTagKey("json"), mergo.WithCollectUnknownKeys(&unknown)); err != nil {
+		t.Fatal(err)
+	}
+	expected := taggedClient{MaxRetries: 3, ServerURL: "https://example.com", Region: "eu-west-1", Timeout: 30}
+	if dst != expected {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+	for _, key := range []string{"token", "Token", "region"} {
+		if _, ok := unknown[key]; !ok {
+			t.Errorf("expected %q to be left out, got %v", key, unknown)
+		}
+	}
+}
+
+func TestTagKeyMapIntoMap(t *testing.T) {
+	src := taggedClient{MaxRetries: 3, ServerURL: "https://example.com", Token: "secret", Region: "eu-west-1", Timeout: 30}
+	dst := map[string]interface{}{}
+
+	if err := mergo.Map(&dst, src, mergo.WithTagKey("json")); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"max_retries": 3,
+		"server-url":  "https://example.com",
+		"zone":        "eu-west-1",
+		"timeout":     30,
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestTagKeyAndTagNameLastWins(t *testing.T) {
+	src := taggedClient{Token: "secret"}
+
+	dst := map[string]interface{}{}
+	if err := mergo.Map(&dst, src, mergo.WithTagKey("json"), mergo.WithTagName("json")); err != nil {
+		t.Fatal(err)
+	}
+	if dst["token"] != "secret" {
+		t.Errorf("expected WithTagName to win and keep the default key of Token, got %v", dst)
+	}
+
+	dst = map[string]interface{}{}
+	if err := mergo.Map(&dst, src, mergo.WithTagName("json"), mergo.WithTagKey("json")); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := dst["token"]; ok {
+		t.Errorf("expected WithTagKey to win and leave Token out, got %v", dst)
+	}
+}
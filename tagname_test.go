@@ -0,0 +1,70 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type taggedAddress struct {
+	Street string `json:"street_name"`
+	City   string
+}
+
+type taggedUser struct {
+	UserID  int           `json:"user_id,omitempty"`
+	Name    string        `json:"-"`
+	Email   string        `json:",omitempty"`
+	Address taggedAddress `json:"address"`
+}
+
+func TestTagName(t *testing.T) {
+	src := taggedUser{UserID: 7, Name: "gopher", Email: "gopher@example.com", Address: taggedAddress{Street: "Main", City: "Springfield"}}
+	out := map[string]interface{}{}
+
+	if err := mergo.Map(&out, src, mergo.WithTagName("json")); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"user_id", "name", "email", "address"} {
+		if _, ok := out[key]; !ok {
+			t.Errorf("expected key %q, got %v", key, out)
+		}
+	}
+	if _, ok := out["userID"]; ok {
+		t.Errorf("expected tagged fields not to use their default key, got %v", out)
+	}
+
+	back := taggedUser{}
+	if err := mergo.Map(&back, out, mergo.WithTagName("json")); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, src) {
+		t.Errorf("expected the round trip to give %+v, got %+v", src, back)
+	}
+}
+
+func TestTagNameNestedMap(t *testing.T) {
+	src := map[string]interface{}{
+		"user_id": 7,
+		"address": map[string]interface{}{"street_name": "Main", "city": "Springfield"},
+	}
+	dst := taggedUser{}
+
+	if err := mergo.Map(&dst, src, mergo.WithTagName("json")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (taggedUser{UserID: 7, Address: taggedAddress{Street: "Main", City: "Springfield"}}); !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+}
+
+func TestTagNameUnset(t *testing.T) {
+	out := map[string]interface{}{}
+	if err := mergo.Map(&out, taggedUser{UserID: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := out["userID"]; !ok {
+		t.Errorf("expected tags to be ignored without the option, got %v", out)
+	}
+}
@@ -0,0 +1,54 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type topLevelItem struct {
+	ID    string
+	Value int
+}
+
+func TestMergeTopLevelSlices(t *testing.T) {
+	var dst []string
+	if err := mergo.Merge(&dst, []string{"a"}, mergo.WithAppendSlice); err != nil {
+		t.Fatal(err)
+	}
+	if err := mergo.Merge(&dst, []string{"b"}, mergo.WithAppendSlice); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v after appending, got %v", expected, dst)
+	}
+
+	if err := mergo.Merge(&dst, []string{"c"}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a", "b"}; !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v to be kept without WithOverride, got %v", expected, dst)
+	}
+	if err := mergo.Merge(&dst, []string{"c"}, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"c"}; !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v after replacing, got %v", expected, dst)
+	}
+}
+
+func TestMergeTopLevelSlicesByKey(t *testing.T) {
+	dst := []topLevelItem{{"a", 1}, {"b", 0}}
+	src := []topLevelItem{{"b", 2}, {"c", 3}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithSliceMergeByKeys("ID")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []topLevelItem{{"a", 1}, {"b", 2}, {"c", 3}}; !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+	if err := mergo.Map(&dst, src); err != mergo.ErrNotSupported {
+		t.Errorf("expected Map to fail with %v, got %v", mergo.ErrNotSupported, err)
+	}
+}
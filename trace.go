@@ -0,0 +1,90 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// TraceAction is the decision taken on a dst value, see TraceEntry.
+type TraceAction string
+
+// Actions reported by MergeWithTrace.
+const (
+	// TraceSet is a dst value replaced by the src one.
+	TraceSet TraceAction = "set"
+	// TraceKeep is a dst value kept over the src one.
+	TraceKeep TraceAction = "keep"
+	// TraceAppend is a dst slice the src one was appended to.
+	TraceAppend TraceAction = "append"
+	// TraceProtect is a dst field kept as it is tagged `mergo:"readonly"`.
+	TraceProtect TraceAction = "protect"
+)
+
+// TraceEntry is a decision taken while merging the value at Path: From is the
+// dst value before it, and To the value after it.
+type TraceEntry struct {
+	Path   string
+	Action TraceAction
+	From   interface{}
+	To     interface{}
+}
+
+// MarshalJSON renders e as a stable JSON object with path, action, from and to
+// keys, so traces can be diffed. Values JSON can't encode, like funcs, are
+// rendered as their %v string.
+func (e TraceEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Path   string          `json:"path"`
+		Action TraceAction     `json:"action"`
+		From   json.RawMessage `json:"from"`
+		To     json.RawMessage `json:"to"`
+	}{e.Path, e.Action, traceJSON(e.From), traceJSON(e.To)})
+}
+
+func traceJSON(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprintf("%v", v))
+	}
+	return data
+}
+
+// MergeWithTrace does the same as Merge but also returns the decisions taken on
+// the values it reached, in the order they were taken. Structs, maps and
+// slices merged element by element are reported through their elements.
+func MergeWithTrace(dst, src interface{}, opts ...func(*Config)) ([]TraceEntry, error) {
+	config := &Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	var entries []TraceEntry
+	config.trace = &entries
+	err := mergeWithConfig(dst, src, config)
+	return entries, err
+}
+
+// traceDecision records the action taken at the current path, if MergeWithTrace
+// is tracing the merge.
+func traceDecision(config *Config, action TraceAction, from, to reflect.Value) {
+	if config.trace == nil {
+		return
+	}
+	*config.trace = append(*config.trace, TraceEntry{
+		Path:   currentPath(config),
+		Action: action,
+		From:   traceValue(from),
+		To:     traceValue(to),
+	})
+}
+
+func traceValue(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
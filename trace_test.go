@@ -0,0 +1,90 @@
+package mergo_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type tracedConfig struct {
+	Name   string
+	Port   int
+	Hosts  []string
+	Labels map[string]string
+}
+
+func TestMergeWithTrace(t *testing.T) {
+	dst := tracedConfig{Name: "dst", Hosts: []string{"a"}, Labels: map[string]string{"env": "dev"}}
+	src := tracedConfig{Name: "src", Port: 80, Hosts: []string{"b"}, Labels: map[string]string{"env": "prod", "team": "core"}}
+
+	trace, err := mergo.MergeWithTrace(&dst, src, mergo.WithAppendSlice, mergo.WithDeterministicOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []mergo.TraceEntry{
+		{Path: "Name", Action: mergo.TraceKeep, From: "dst", To: "dst"},
+		{Path: "Port", Action: mergo.TraceSet, From: 0, To: 80},
+		{Path: "Hosts", Action: mergo.TraceAppend, From: []string{"a"}, To: []string{"a", "b"}},
+		{Path: "Labels[env]", Action: mergo.TraceKeep, From: "dev", To: "dev"},
+		{Path: "Labels[team]", Action: mergo.TraceSet, From: nil, To: "core"},
+	}
+	if !reflect.DeepEqual(trace, expected) {
+		t.Errorf("expected %#v, got %#v", expected, trace)
+	}
+}
+
+func TestMergeWithTraceOverride(t *testing.T) {
+	dst := tracedConfig{Name: "dst", Port: 80}
+	src := tracedConfig{Name: "src"}
+
+	trace, err := mergo.MergeWithTrace(&dst, src, mergo.WithOverride)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []mergo.TraceEntry{
+		{Path: "Name", Action: mergo.TraceSet, From: "dst", To: "src"},
+		{Path: "Port", Action: mergo.TraceKeep, From: 80, To: 80},
+		{Path: "Hosts", Action: mergo.TraceKeep, From: []string(nil), To: []string(nil)},
+	}
+	if !reflect.DeepEqual(trace, expected) {
+		t.Errorf("expected %#v, got %#v", expected, trace)
+	}
+}
+
+func TestTraceJSON(t *testing.T) {
+	type service struct {
+		Name     string
+		Ports    []int
+		Callback func()
+	}
+	dst := service{Name: "dst"}
+	src := service{Name: "src", Ports: []int{80}, Callback: func() {}}
+
+	entries, err := mergo.MergeWithTrace(&dst, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rendered []map[string]interface{}
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		t.Fatal(err)
+	}
+	if len(rendered) != 3 {
+		t.Fatalf("expected 3 entries, got %s", data)
+	}
+	if expected := `{"path":"Name","action":"keep","from":"dst","to":"dst"}`; !strings.Contains(string(data), expected) {
+		t.Errorf("expected %s in %s", expected, data)
+	}
+	if expected := `{"path":"Ports","action":"set","from":null,"to":[80]}`; !strings.Contains(string(data), expected) {
+		t.Errorf("expected %s in %s", expected, data)
+	}
+	if to, ok := rendered[2]["to"].(string); rendered[2]["path"] != "Callback" || !ok || !strings.HasPrefix(to, "0x") {
+		t.Errorf("expected the func to be rendered as a string, got %v", rendered[2])
+	}
+}
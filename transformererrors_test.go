@@ -0,0 +1,94 @@
+package mergo_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type secret string
+
+type secretHolder struct {
+	D secret
+}
+
+type secretsConfig struct {
+	A      secret
+	B      secret
+	C      int
+	Nested secretHolder
+}
+
+var errBadSecret = errors.New("bad secret")
+
+func failingSecretTransformer() mergo.Transformers {
+	return &transformer{
+		m: map[reflect.Type]func(dst, src reflect.Value) error{
+			reflect.TypeOf(secret("")): func(dst, src reflect.Value) error {
+				if src.String() == "bad" {
+					return errBadSecret
+				}
+				if dst.CanSet() {
+					dst.Set(src)
+				}
+				return nil
+			},
+		},
+	}
+}
+
+func newSecretsConfig() secretsConfig {
+	return secretsConfig{A: "a", B: "b", Nested: secretHolder{D: "d"}}
+}
+
+func TestTransformerErrorsFailFast(t *testing.T) {
+	dst := newSecretsConfig()
+	src := secretsConfig{A: "bad", B: "bad", C: 3}
+
+	err := mergo.Merge(&dst, src, mergo.WithTransformers(failingSecretTransformer()))
+	if err != errBadSecret {
+		t.Fatalf("expected %v, got %v", errBadSecret, err)
+	}
+	if dst.C != 0 {
+		t.Errorf("expected the merge to stop at the first error, got C=%d", dst.C)
+	}
+}
+
+func TestTransformerErrorsAggregated(t *testing.T) {
+	dst := newSecretsConfig()
+	src := secretsConfig{A: "bad", B: "new", C: 3, Nested: secretHolder{D: "bad"}}
+
+	err := mergo.Merge(&dst, src, mergo.WithTransformers(failingSecretTransformer()), mergo.WithAggregateTransformerErrors)
+	var errs mergo.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected mergo.Errors, got %v", err)
+	}
+	var paths []string
+	for _, e := range errs {
+		var terr *mergo.TransformerError
+		if !errors.As(e, &terr) || terr.Err != errBadSecret {
+			t.Fatalf("expected a *mergo.TransformerError wrapping %v, got %v", errBadSecret, e)
+		}
+		paths = append(paths, terr.Path)
+	}
+	if expected := []string{"A", "Nested.D"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected paths %v, got %v", expected, paths)
+	}
+	if !errors.Is(err, errBadSecret) {
+		t.Error("expected errors.Is to match the transformer error")
+	}
+	expected := secretsConfig{A: "a", B: "new", C: 3, Nested: secretHolder{D: "d"}}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected successful fields to be merged: %v, got %v", expected, dst)
+	}
+}
+
+func TestTransformerErrorsAggregatedNone(t *testing.T) {
+	dst := newSecretsConfig()
+	src := secretsConfig{A: "new"}
+	if err := mergo.Merge(&dst, src, mergo.WithTransformers(failingSecretTransformer()), mergo.WithAggregateTransformerErrors); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "reflect"
+
+// interfaceTransformer is a transformer registered by WithInterfaceTransformer.
+type interfaceTransformer struct {
+	iface reflect.Type
+	fn    func(dst, src reflect.Value) error
+}
+
+// WithTypeTransformer will make merge use fn to merge values of type typ.
+func WithTypeTransformer(typ reflect.Type, fn func(dst, src reflect.Value) error) func(*Config) {
+	return func(config *Config) {
+		if config.typeTransformers == nil {
+			config.typeTransformers = make(map[reflect.Type]func(dst, src reflect.Value) error)
+		}
+		config.typeTransformers[typ] = fn
+	}
+}
+
+// WithInterfaceTransformer will make merge use fn to merge values of any type
+// implementing iface, an interface type like reflect.TypeOf((*fmt.Stringer)(nil)).Elem().
+// When a type implements several of them, the first one registered wins.
+func WithInterfaceTransformer(iface reflect.Type, fn func(dst, src reflect.Value) error) func(*Config) {
+	return func(config *Config) {
+		config.interfaceTransformers = append(config.interfaceTransformers, interfaceTransformer{iface, fn})
+	}
+}
+
+// WithKindTransformer will make merge use fn to merge values of kind, like all
+// the strings whatever their type. When several transformers could merge a
+// value, the most specific one is used: first the one given by WithTransformers
+// for its type, then the one registered with WithTypeTransformer, then with
+// WithInterfaceTransformer, and then with WithKindTransformer. Values without
+// any are merged as usual.
+func WithKindTransformer(kind reflect.Kind, fn func(dst, src reflect.Value) error) func(*Config) {
+	return func(config *Config) {
+		if config.kindTransformers == nil {
+			config.kindTransformers = make(map[reflect.Kind]func(dst, src reflect.Value) error)
+		}
+		config.kindTransformers[kind] = fn
+	}
+}
+
+// hasTransformers reports whether any transformer is registered in config.
+func hasTransformers(config *Config) bool {
+	return config.Transformers != nil || len(config.typeTransformers) > 0 || len(config.interfaceTransformers) > 0 || len(config.kindTransformers) > 0
+}
+
+// transformerFor returns the most specific transformer of config for typ, if
+// any, see WithKindTransformer.
+func transformerFor(typ reflect.Type, config *Config) func(dst, src reflect.Value) error {
+	if config.Transformers != nil {
+		if aware, ok := config.Transformers.(ContextTransformers); ok {
+			if fn := aware.ContextTransformer(typ); fn != nil {
+				ctx := (&HookContext{config}).Context()
+				return func(dst, src reflect.Value) error {
+					return fn(ctx, dst, src)
+				}
+			}
+		}
+		if scoped, ok := config.Transformers.(ScopedTransformers); ok {
+			if fn := scoped.ScopedTransformer(typ); fn != nil {
+				ctx := &HookContext{config}
+				return func(dst, src reflect.Value) error {
+					return fn(ctx, dst, src)
+				}
+			}
+		}
+		if fn := config.Transformers.Transformer(typ); fn != nil {
+			return fn
+		}
+	}
+	if fn := config.typeTransformers[typ]; fn != nil {
+		return fn
+	}
+	for _, t := range config.interfaceTransformers {
+		if typ.Implements(t.iface) {
+			return t.fn
+		}
+	}
+	return config.kindTransformers[typ.Kind()]
+}
@@ -0,0 +1,77 @@
+package mergo_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type celsius float64
+
+func (c celsius) String() string { return fmt.Sprintf("%.1f°C", float64(c)) }
+
+type fahrenheit float64
+
+func (f fahrenheit) String() string { return fmt.Sprintf("%.1f°F", float64(f)) }
+
+type readings struct {
+	Indoor  celsius
+	Outdoor fahrenheit
+	Ratio   float64
+	Count   int
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+func setFloat(value float64) func(dst, src reflect.Value) error {
+	return func(dst, src reflect.Value) error {
+		dst.SetFloat(value)
+		return nil
+	}
+}
+
+func TestTransformerPrecedence(t *testing.T) {
+	dst := readings{Indoor: 1, Outdoor: 1, Ratio: 1, Count: 1}
+	src := readings{Indoor: 2, Outdoor: 2, Ratio: 2, Count: 2}
+
+	err := mergo.Merge(&dst, src, mergo.WithOverride,
+		mergo.WithKindTransformer(reflect.Float64, setFloat(30)),
+		mergo.WithInterfaceTransformer(stringerType, setFloat(20)),
+		mergo.WithTypeTransformer(reflect.TypeOf(celsius(0)), setFloat(10)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// celsius matches all three levels, fahrenheit the interface and kind
+	// ones, float64 only the kind one and int none.
+	expected := readings{Indoor: 10, Outdoor: 20, Ratio: 30, Count: 2}
+	if dst != expected {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+}
+
+type celsiusTransformers struct{}
+
+func (celsiusTransformers) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	if typ == reflect.TypeOf(celsius(0)) {
+		return setFloat(5)
+	}
+	return nil
+}
+
+func TestTransformersBeforeTypeTransformer(t *testing.T) {
+	dst := readings{Indoor: 1, Outdoor: 1}
+	err := mergo.Merge(&dst, readings{Indoor: 2, Outdoor: 2}, mergo.WithOverride,
+		mergo.WithTransformers(celsiusTransformers{}),
+		mergo.WithTypeTransformer(reflect.TypeOf(celsius(0)), setFloat(10)),
+		mergo.WithTypeTransformer(reflect.TypeOf(fahrenheit(0)), setFloat(10)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (readings{Indoor: 5, Outdoor: 10}); dst != expected {
+		t.Errorf("expected %+v, got %+v", expected, dst)
+	}
+}
@@ -0,0 +1,83 @@
+package mergo_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type envConfig struct {
+	Host    string
+	Port    int
+	Debug   bool
+	Ratio   float64
+	Payload json.RawMessage
+	Nested  struct{ Name string }
+}
+
+type envKey string
+
+func TestMapIntoStringMap(t *testing.T) {
+	src := struct {
+		Host  string
+		Port  int
+		Debug bool
+		Ratio float64
+	}{"localhost", 8080, true, 0.5}
+	dst := map[string]string{"host": "example.com", "port": ""}
+
+	if err := mergo.Map(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"host": "example.com", "port": "8080", "debug": "true", "ratio": "0.5"}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+
+	if err := mergo.Map(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst["host"] != "localhost" {
+		t.Errorf("expected host to be overridden, got %v", dst)
+	}
+}
+
+func TestMapIntoTypedMaps(t *testing.T) {
+	src := struct {
+		Payload json.RawMessage
+		Other   []byte
+	}{json.RawMessage(`{"a":1}`), []byte(`[1]`)}
+	raw := map[string]json.RawMessage{}
+	if err := mergo.Map(&raw, src); err != nil {
+		t.Fatal(err)
+	}
+	if string(raw["payload"]) != `{"a":1}` || string(raw["other"]) != `[1]` {
+		t.Errorf("unexpected raw messages %v", raw)
+	}
+
+	numbers := map[envKey]int64{}
+	if err := mergo.Map(&numbers, struct{ A, B int8 }{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (map[envKey]int64{"a": 1, "b": 2}); !reflect.DeepEqual(numbers, expected) {
+		t.Errorf("expected %v, got %v", expected, numbers)
+	}
+}
+
+func TestMapIntoTypedMapMismatch(t *testing.T) {
+	dst := map[string]string{}
+	err := mergo.Map(&dst, envConfig{Nested: struct{ Name string }{"n"}})
+	if err == nil || !strings.Contains(err.Error(), "type mismatch on Payload field") {
+		t.Errorf("expected a type mismatch, got %v", err)
+	}
+
+	keys := map[int]interface{}{}
+	err = mergo.Map(&keys, envConfig{})
+	if !errors.Is(err, mergo.ErrNotSupported) {
+		t.Errorf("expected %v for int keys, got %v", mergo.ErrNotSupported, err)
+	}
+}
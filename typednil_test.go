@@ -0,0 +1,72 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type typedNilConfig struct {
+	S []int
+	M map[string]int
+}
+
+func newTypedNilConfig() typedNilConfig {
+	return typedNilConfig{S: []int{1, 2}, M: map[string]int{"a": 1}}
+}
+
+func TestTypedNilFieldsInStructs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      typedNilConfig
+		options  []func(*mergo.Config)
+		expected typedNilConfig
+	}{
+		{"fill with nil", typedNilConfig{S: nil, M: nil}, nil, newTypedNilConfig()},
+		{"override with nil", typedNilConfig{S: nil, M: nil}, []func(*mergo.Config){mergo.WithOverride}, newTypedNilConfig()},
+		{"override empty slice with nil", typedNilConfig{S: nil}, []func(*mergo.Config){mergo.WithOverride, mergo.WithOverrideEmptySlice}, newTypedNilConfig()},
+		{
+			"override empty slice with empty",
+			typedNilConfig{S: []int{}},
+			[]func(*mergo.Config){mergo.WithOverride, mergo.WithOverrideEmptySlice},
+			typedNilConfig{S: []int{}, M: map[string]int{"a": 1}},
+		},
+		{"clear with nil", typedNilConfig{S: nil, M: nil}, []func(*mergo.Config){mergo.WithOverwriteWithEmptyValue}, typedNilConfig{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := newTypedNilConfig()
+			if err := mergo.Merge(&dst, tc.src, tc.options...); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(dst, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, dst)
+			}
+		})
+	}
+}
+
+func TestTypedNilValuesInMaps(t *testing.T) {
+	newDst := func() map[string]interface{} {
+		return map[string]interface{}{"a": []int{1}, "b": []int{2}, "c": map[string]int{"c": 3}}
+	}
+	src := map[string]interface{}{"a": ([]int)(nil), "c": (map[string]int)(nil)}
+
+	dst := newDst()
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst, newDst()) {
+		t.Errorf("expected typed nils to be skipped without override, got %v", dst)
+	}
+
+	dst = newDst()
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"a": ([]int)(nil), "b": []int{2}, "c": (map[string]int)(nil)}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected typed nils to clear their keys and missing keys to be kept, got %v", dst)
+	}
+}
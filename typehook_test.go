@@ -0,0 +1,64 @@
+package mergo_test
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type checksummedPayload struct {
+	Parts    []string
+	Checksum uint32
+}
+
+type checksummedMessage struct {
+	ID      string
+	Payload checksummedPayload
+}
+
+func recomputeChecksum(dst, src reflect.Value) error {
+	payload := dst.Addr().Interface().(*checksummedPayload)
+	payload.Checksum = crc32.ChecksumIEEE([]byte(strings.Join(payload.Parts, ",")))
+	return nil
+}
+
+func TestTypeHook(t *testing.T) {
+	dst := checksummedMessage{ID: "dst", Payload: checksummedPayload{Parts: []string{"a"}, Checksum: 1}}
+	src := checksummedMessage{Payload: checksummedPayload{Parts: []string{"b"}, Checksum: 2}}
+	var before []string
+	hook := mergo.WithTypeHook(reflect.TypeOf(checksummedPayload{}), func(dst, src reflect.Value) error {
+		before = append(before, fmt.Sprint(dst.Interface()))
+		return nil
+	}, recomputeChecksum)
+
+	if err := mergo.Merge(&dst, src, mergo.WithAppendSlice, hook); err != nil {
+		t.Fatal(err)
+	}
+	if expected := crc32.ChecksumIEEE([]byte("a,b")); dst.Payload.Checksum != expected {
+		t.Errorf("expected checksum %d, got %d", expected, dst.Payload.Checksum)
+	}
+	if len(before) != 1 || before[0] != "{[a] 1}" {
+		t.Errorf("expected the before hook to see dst once before the merge, got %v", before)
+	}
+}
+
+func TestTypeHookError(t *testing.T) {
+	errHook := errors.New("hook failed")
+	dst := checksummedMessage{ID: "dst"}
+	src := checksummedMessage{ID: "src", Payload: checksummedPayload{Parts: []string{"b"}}}
+	hook := mergo.WithTypeHook(reflect.TypeOf(checksummedPayload{}), func(dst, src reflect.Value) error {
+		return errHook
+	}, nil)
+
+	if err := mergo.Merge(&dst, src, hook); !errors.Is(err, errHook) {
+		t.Errorf("expected the hook error, got %v", err)
+	}
+	if len(dst.Payload.Parts) != 0 {
+		t.Errorf("expected payload not to be merged, got %v", dst.Payload)
+	}
+}
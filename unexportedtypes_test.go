@@ -0,0 +1,70 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type internalState struct {
+	Counter int
+}
+
+type withUnexportedTypes struct {
+	Name     string
+	State    internalState
+	StatePtr *internalState
+	States   map[string]internalState
+	Count    int
+}
+
+func TestIgnoreUnexportedTypes(t *testing.T) {
+	dst := withUnexportedTypes{}
+	src := withUnexportedTypes{
+		Name:     "src",
+		State:    internalState{Counter: 1},
+		StatePtr: &internalState{Counter: 2},
+		States:   map[string]internalState{"a": {Counter: 3}},
+		Count:    4,
+	}
+
+	if err := mergo.Merge(&dst, src, mergo.WithIgnoreUnexportedTypes); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (withUnexportedTypes{Name: "src", Count: 4}); !reflect.DeepEqual(dst, expected) {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+
+	dst = withUnexportedTypes{}
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.State.Counter != 1 || dst.StatePtr == nil {
+		t.Errorf("expected unexported types to be merged without the option, got %v", dst)
+	}
+}
+
+func TestIgnoreUnexportedTypesMap(t *testing.T) {
+	src := withUnexportedTypes{Name: "src", State: internalState{Counter: 1}}
+	dst := map[string]interface{}{}
+
+	if err := mergo.Map(&dst, src, mergo.WithIgnoreUnexportedTypes); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := dst["state"]; ok {
+		t.Errorf("expected state to be skipped, got %v", dst)
+	}
+	if dst["name"] != "src" {
+		t.Errorf("expected name to be mapped, got %v", dst)
+	}
+
+	out := withUnexportedTypes{}
+	in := map[string]interface{}{"name": "in", "state": internalState{Counter: 5}}
+	if err := mergo.Map(&out, in, mergo.WithIgnoreUnexportedTypes); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "in" || out.State.Counter != 0 {
+		t.Errorf("expected only name to be bound, got %v", out)
+	}
+}
@@ -0,0 +1,52 @@
+package mergo_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type knownTLS struct {
+	Cert string
+}
+
+type knownConfig struct {
+	Name string
+	Port int
+	TLS  knownTLS
+}
+
+func TestCollectUnknownKeys(t *testing.T) {
+	var unknown map[string]interface{}
+	dst := knownConfig{}
+	src := map[string]interface{}{
+		"name":    "api",
+		"port":    80,
+		"extra":   []int{1, 2},
+		"comment": "kept",
+		"tLS":     map[string]interface{}{"cert": "a.crt", "ciphers": "all"},
+	}
+
+	if err := mergo.Map(&dst, src, mergo.WithCollectUnknownKeys(&unknown)); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"extra": []int{1, 2}, "comment": "kept", "tLS.ciphers": "all"}
+	if !reflect.DeepEqual(unknown, expected) {
+		t.Errorf("expected %v, got %v", expected, unknown)
+	}
+	if dst != (knownConfig{Name: "api", Port: 80, TLS: knownTLS{Cert: "a.crt"}}) {
+		t.Errorf("expected known keys to be bound, got %+v", dst)
+	}
+}
+
+func TestCollectUnknownKeysNone(t *testing.T) {
+	unknown := map[string]interface{}{"previous": true}
+	dst := knownConfig{}
+	if err := mergo.Map(&dst, map[string]interface{}{"name": "api"}, mergo.WithCollectUnknownKeys(&unknown)); err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]interface{}{"previous": true}; !reflect.DeepEqual(unknown, expected) {
+		t.Errorf("expected no key to be collected, got %v", unknown)
+	}
+}
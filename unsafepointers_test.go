@@ -0,0 +1,48 @@
+package mergo_test
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/imdario/mergo"
+)
+
+type handleConfig struct {
+	Name   string
+	Handle uintptr
+	Data   unsafe.Pointer
+}
+
+func TestUnsafePointersSkipped(t *testing.T) {
+	value := 1
+	dst := handleConfig{}
+	src := handleConfig{Name: "src", Handle: 42, Data: unsafe.Pointer(&value)}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "src" || dst.Handle != 0 || dst.Data != nil {
+		t.Errorf("expected uintptr and unsafe.Pointer fields to be skipped, got %+v", dst)
+	}
+}
+
+func TestCopyUnsafePointers(t *testing.T) {
+	value, other := 1, 2
+	dst := handleConfig{Handle: 7}
+	src := handleConfig{Handle: 42, Data: unsafe.Pointer(&value)}
+
+	if err := mergo.Merge(&dst, src, mergo.WithCopyUnsafePointers); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Handle != 7 || dst.Data != unsafe.Pointer(&value) {
+		t.Errorf("expected empty fields to be filled, got %+v", dst)
+	}
+
+	src = handleConfig{Handle: 42, Data: unsafe.Pointer(&other)}
+	if err := mergo.Merge(&dst, src, mergo.WithCopyUnsafePointers, mergo.WithOverride); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Handle != 42 || dst.Data != unsafe.Pointer(&other) {
+		t.Errorf("expected fields to be overridden, got %+v", dst)
+	}
+}
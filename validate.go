@@ -0,0 +1,114 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ValidationError is a violation of a validate tag rule found by
+// WithSchemaValidation.
+type ValidationError struct {
+	Path string
+	Rule string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation failed on %s: %s", e.Path, e.Rule)
+}
+
+// validate checks the validate tags of the fields of v, a struct, recursively,
+// collecting their violations into config.errors. It fails if a rule is
+// malformed. seen holds the pointers already followed, so cycles end.
+func validate(v reflect.Value, seen map[uintptr]bool, config *Config) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() || seen[v.Pointer()] {
+			return nil
+		}
+		seen[v.Pointer()] = true
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	typ := v.Type()
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		field := typ.Field(i)
+		if !isExported(field) {
+			continue
+		}
+		pushField(config, field.Name)
+		err := validateField(v.Field(i), field.Tag.Get("validate"), config)
+		if err == nil {
+			err = validate(v.Field(i), seen, config)
+		}
+		popPath(config)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateField checks v against rules, a comma-separated list of required,
+// nonempty, min=N and max=N. Bounds apply to numbers, or to the length of
+// strings, slices and maps. Unknown rules are ignored.
+func validateField(v reflect.Value, rules string, config *Config) error {
+	if rules == "" {
+		return nil
+	}
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg := rule, ""
+		if i := strings.Index(rule, "="); i != -1 {
+			name, arg = rule[:i], rule[i+1:]
+		}
+		var ok bool
+		switch name {
+		case "required":
+			ok = !isEmptyValue(v) && (v.Kind() != reflect.Struct || !isEmptyStruct(v))
+		case "nonempty":
+			ok = !hasLen(v) || v.Len() > 0
+		case "min", "max":
+			bound, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s rule on %s: %w", name, currentPath(config), err)
+			}
+			size, measurable := measure(v)
+			ok = !measurable || (name == "min" && size >= bound) || (name == "max" && size <= bound)
+		default:
+			ok = true
+		}
+		if !ok {
+			config.errors = append(config.errors, &ValidationError{Path: currentPath(config), Rule: rule})
+		}
+	}
+	return nil
+}
+
+func hasLen(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// measure returns the number held by v, or its length.
+func measure(v reflect.Value) (float64, bool) {
+	switch {
+	case isIntKind(v.Kind()):
+		return float64(v.Int()), true
+	case isUintKind(v.Kind()):
+		return float64(v.Uint()), true
+	case isFloatKind(v.Kind()):
+		return v.Float(), true
+	case hasLen(v):
+		return float64(v.Len()), true
+	}
+	return 0, false
+}
@@ -0,0 +1,77 @@
+package mergo_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type validatedTLS struct {
+	Cert string `validate:"required"`
+}
+
+type validatedConfig struct {
+	Name      string        `validate:"required,nonempty"`
+	Port      int           `validate:"min=1,max=65535"`
+	Ratio     float64       `validate:"max=1"`
+	Hosts     []string      `validate:"min=1"`
+	TLS       *validatedTLS `validate:"required"`
+	Comment   string        `validate:"email"`
+	Unrelated int
+}
+
+func validationRules(t *testing.T, err error) []string {
+	t.Helper()
+	var errs mergo.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected mergo.Errors, got %v", err)
+	}
+	var rules []string
+	for _, e := range errs {
+		var verr *mergo.ValidationError
+		if !errors.As(e, &verr) {
+			t.Fatalf("expected a *mergo.ValidationError, got %v", e)
+		}
+		rules = append(rules, verr.Path+":"+verr.Rule)
+	}
+	return rules
+}
+
+func TestSchemaValidationRequired(t *testing.T) {
+	dst := validatedConfig{Port: 80, Hosts: []string{"a"}, TLS: &validatedTLS{}}
+	err := mergo.Merge(&dst, validatedConfig{Ratio: 0.5}, mergo.WithSchemaValidation)
+	if rules := strings.Join(validationRules(t, err), " "); rules != "Name:required Name:nonempty TLS.Cert:required" {
+		t.Errorf("unexpected violations: %s", rules)
+	}
+	if dst.Ratio != 0.5 {
+		t.Errorf("expected the merge to be applied, got %+v", dst)
+	}
+}
+
+func TestSchemaValidationRanges(t *testing.T) {
+	dst := validatedConfig{Name: "api", TLS: &validatedTLS{Cert: "a.crt"}}
+	err := mergo.Merge(&dst, validatedConfig{Port: 70000, Ratio: 1.5}, mergo.WithSchemaValidation)
+	if rules := strings.Join(validationRules(t, err), " "); rules != "Port:max=65535 Ratio:max=1 Hosts:min=1" {
+		t.Errorf("unexpected violations: %s", rules)
+	}
+}
+
+func TestSchemaValidationValid(t *testing.T) {
+	dst := validatedConfig{}
+	src := map[string]interface{}{"name": "api", "port": 443, "hosts": []string{"a"}, "tLS": &validatedTLS{Cert: "a.crt"}}
+	if err := mergo.Map(&dst, src, mergo.WithSchemaValidation); err != nil {
+		t.Errorf("expected no violations, got %v", err)
+	}
+}
+
+func TestSchemaValidationMalformedRule(t *testing.T) {
+	dst := struct {
+		Port int `validate:"min=one"`
+	}{}
+	err := mergo.Merge(&dst, dst, mergo.WithSchemaValidation)
+	if err == nil || !strings.Contains(err.Error(), "invalid min rule on Port") {
+		t.Errorf("expected a malformed rule error, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type cyclicLeaf struct {
+	Value string
+}
+
+// cyclicSiblings nests leaves at the same address as well.
+type cyclicSiblings struct {
+	First  cyclicLeaf
+	Second cyclicLeaf
+}
+
+type cyclicNode struct {
+	// Leaf shares the address of the node holding it.
+	Leaf     cyclicLeaf
+	Self     *cyclicNode
+	Siblings cyclicSiblings
+	Name     string
+}
+
+func TestVisitedCycles(t *testing.T) {
+	dst := &cyclicNode{}
+	dst.Self = dst
+	src := &cyclicNode{Leaf: cyclicLeaf{"leaf"}, Name: "src"}
+	src.Siblings = cyclicSiblings{cyclicLeaf{"first"}, cyclicLeaf{"second"}}
+	src.Self = src
+
+	if err := mergo.Merge(dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Leaf.Value != "leaf" || dst.Name != "src" {
+		t.Errorf("expected the fields around the cycle to be merged, got %+v", dst)
+	}
+	if dst.Siblings != src.Siblings {
+		t.Errorf("expected the siblings to be merged, got %+v", dst.Siblings)
+	}
+	if dst.Self != dst {
+		t.Errorf("expected the cycle to be kept")
+	}
+}
+
+func TestVisitedMapCycles(t *testing.T) {
+	dst := &cyclicNode{}
+	dst.Self = dst
+	src := map[string]interface{}{
+		"leaf": map[string]interface{}{"value": "leaf"},
+		"name": "src",
+	}
+
+	if err := mergo.Map(dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Leaf.Value != "leaf" || dst.Name != "src" {
+		t.Errorf("expected the first field to be mapped apart from its struct, got %+v", dst)
+	}
+}
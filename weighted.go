@@ -0,0 +1,33 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import "sort"
+
+// WeightedSource is a source for MergeWeighted along with its precedence.
+type WeightedSource struct {
+	Src    interface{}
+	Weight int
+}
+
+// MergeWeighted merges every source into dst so that, for each field, the
+// non-empty value from the source with the highest weight wins. Sources with
+// the same weight are resolved by their order: the first one wins.
+// Sources are applied with WithOverride from the lowest to the highest
+// precedence, so empty values never hide a lower-weight non-empty one.
+func MergeWeighted(dst interface{}, sources []WeightedSource, opts ...func(*Config)) error {
+	sorted := make([]WeightedSource, len(sources))
+	copy(sorted, sources)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Weight > sorted[j].Weight
+	})
+	opts = append(opts[:len(opts):len(opts)], WithOverride)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if err := merge(dst, sorted[i].Src, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package mergo_test
+
+import (
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type weightedConfig struct {
+	Host    string
+	Port    int
+	Timeout int
+	Name    string
+}
+
+func TestMergeWeighted(t *testing.T) {
+	dst := weightedConfig{Name: "dst"}
+	sources := []mergo.WeightedSource{
+		{Src: weightedConfig{Host: "defaults", Port: 80, Timeout: 30}, Weight: 0},
+		{Src: weightedConfig{Host: "env", Port: 8080}, Weight: 10},
+		{Src: weightedConfig{Host: "file"}, Weight: 5},
+	}
+
+	if err := mergo.MergeWeighted(&dst, sources); err != nil {
+		t.Fatal(err)
+	}
+	expected := weightedConfig{Host: "env", Port: 8080, Timeout: 30, Name: "dst"}
+	if dst != expected {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestMergeWeightedTies(t *testing.T) {
+	dst := weightedConfig{}
+	sources := []mergo.WeightedSource{
+		{Src: weightedConfig{Host: "first"}, Weight: 1},
+		{Src: weightedConfig{Host: "second", Port: 2}, Weight: 1},
+	}
+
+	if err := mergo.MergeWeighted(&dst, sources); err != nil {
+		t.Fatal(err)
+	}
+	expected := weightedConfig{Host: "first", Port: 2}
+	if dst != expected {
+		t.Errorf("expected %v, got %v", expected, dst)
+	}
+}
+
+func TestMergeWeightedError(t *testing.T) {
+	dst := weightedConfig{}
+	sources := []mergo.WeightedSource{{Src: 42, Weight: 1}}
+	if err := mergo.MergeWeighted(&dst, sources); err != mergo.ErrDifferentArgumentsTypes {
+		t.Errorf("expected %v, got %v", mergo.ErrDifferentArgumentsTypes, err)
+	}
+}
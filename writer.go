@@ -0,0 +1,62 @@
+// Copyright 2021 Dario Castañé. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mergo
+
+import (
+	"io"
+	"reflect"
+)
+
+// writeInto writes the bytes held by src into dst if it is an io.Writer, see
+// WithWriterFields. It reports whether src was written.
+func writeInto(dst, src reflect.Value) (bool, error) {
+	w, ok := writerOf(dst)
+	if !ok {
+		return false, nil
+	}
+	data, ok := bytesOf(src)
+	if !ok {
+		return false, nil
+	}
+	if src.Kind() == reflect.Ptr && dst.Kind() == reflect.Ptr && src.Pointer() == dst.Pointer() {
+		// Writing a buffer into itself would duplicate its content.
+		return true, nil
+	}
+	_, err := w.Write(data)
+	return true, err
+}
+
+func writerOf(v reflect.Value) (io.Writer, bool) {
+	if isReflectNil(v) || !v.CanInterface() {
+		return nil, false
+	}
+	if w, ok := v.Interface().(io.Writer); ok {
+		return w, true
+	}
+	if v.CanAddr() {
+		w, ok := v.Addr().Interface().(io.Writer)
+		return w, ok
+	}
+	return nil, false
+}
+
+func bytesOf(v reflect.Value) ([]byte, bool) {
+	v = unwrapInterface(v)
+	if !v.IsValid() || isReflectNil(v) || !v.CanInterface() {
+		return nil, false
+	}
+	if v.Kind() == reflect.Struct && v.CanAddr() {
+		v = v.Addr()
+	}
+	switch data := v.Interface().(type) {
+	case []byte:
+		return data, true
+	case string:
+		return []byte(data), true
+	case interface{ Bytes() []byte }:
+		return data.Bytes(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,55 @@
+package mergo_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/imdario/mergo"
+)
+
+type sinkConfig struct {
+	Name string
+	Log  *bytes.Buffer
+	Out  bytes.Buffer
+}
+
+func TestWriterFields(t *testing.T) {
+	dst := sinkConfig{Log: bytes.NewBufferString("first;")}
+	dst.Out.WriteString("a;")
+	src := sinkConfig{Name: "src", Log: bytes.NewBufferString("second;")}
+	src.Out.WriteString("b;")
+
+	if err := mergo.Merge(&dst, &src, mergo.WithWriterFields); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Log.String() != "first;second;" || dst.Out.String() != "a;b;" {
+		t.Errorf("expected buffers to accumulate, got %q and %q", dst.Log.String(), dst.Out.String())
+	}
+	if dst.Name != "src" {
+		t.Errorf("expected other fields to be merged as usual, got %q", dst.Name)
+	}
+}
+
+func TestWriterFieldsMap(t *testing.T) {
+	log := bytes.NewBufferString("first;")
+	dst := sinkConfig{Log: log}
+	src := map[string]interface{}{"log": []byte("second;"), "out": "out;"}
+
+	if err := mergo.Map(&dst, src, mergo.WithWriterFields); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Log != log || log.String() != "first;second;" || dst.Out.String() != "out;" {
+		t.Errorf("expected map values to be written, got %q and %q", log.String(), dst.Out.String())
+	}
+}
+
+func TestWriterFieldsDisabled(t *testing.T) {
+	dst := sinkConfig{}
+	src := sinkConfig{Log: bytes.NewBufferString("src")}
+	if err := mergo.Merge(&dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Log != src.Log {
+		t.Error("expected the buffer to be assigned without the option")
+	}
+}
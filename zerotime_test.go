@@ -0,0 +1,59 @@
+package mergo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/imdario/mergo"
+)
+
+type auditedRecord struct {
+	Name      string
+	CreatedAt time.Time
+	DeletedAt *time.Time
+}
+
+func TestSkipZeroTimeOverwrite(t *testing.T) {
+	created := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	deleted := created.Add(time.Hour)
+	dst := auditedRecord{Name: "dst", CreatedAt: created, DeletedAt: &deleted}
+	src := auditedRecord{Name: "src", DeletedAt: &time.Time{}}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithSkipZeroTimeOverwrite); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "src" {
+		t.Errorf("expected name to be overwritten, got %q", dst.Name)
+	}
+	if !dst.CreatedAt.Equal(created) || dst.DeletedAt == nil || !dst.DeletedAt.Equal(deleted) {
+		t.Errorf("expected times to be kept, got %v and %v", dst.CreatedAt, dst.DeletedAt)
+	}
+
+	if err := mergo.Merge(&dst, auditedRecord{}, mergo.WithOverwriteWithEmptyValue, mergo.WithSkipZeroTimeOverwrite); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.CreatedAt.Equal(created) || dst.DeletedAt == nil {
+		t.Errorf("expected times to be kept with empty values, got %v and %v", dst.CreatedAt, dst.DeletedAt)
+	}
+}
+
+func TestSkipZeroTimeOverwriteNonZero(t *testing.T) {
+	created := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	dst := auditedRecord{CreatedAt: created}
+	src := auditedRecord{CreatedAt: created.Add(time.Hour)}
+
+	if err := mergo.Merge(&dst, src, mergo.WithOverride, mergo.WithSkipZeroTimeOverwrite); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.CreatedAt.Equal(src.CreatedAt) {
+		t.Errorf("expected a non-zero time to overwrite, got %v", dst.CreatedAt)
+	}
+
+	dst = auditedRecord{CreatedAt: created}
+	if err := mergo.Merge(&dst, auditedRecord{}, mergo.WithOverwriteWithEmptyValue); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.CreatedAt.IsZero() {
+		t.Errorf("expected the zero time to overwrite without the option, got %v", dst.CreatedAt)
+	}
+}